@@ -7,8 +7,12 @@ import (
 	"github.com/ambientsound/visp/input/keys"
 	"github.com/ambientsound/visp/list"
 	"github.com/ambientsound/visp/multibar"
+	"github.com/ambientsound/visp/pkg/macro"
+	"github.com/ambientsound/visp/pkg/stylerule"
 	"github.com/ambientsound/visp/player"
 	"github.com/ambientsound/visp/spotify/library"
+	"github.com/ambientsound/visp/spotify/likes"
+	"github.com/ambientsound/visp/spotify/nowplaying"
 	"github.com/ambientsound/visp/style"
 	"github.com/zmb3/spotify/v2"
 	"golang.org/x/oauth2"
@@ -42,24 +46,40 @@ type API interface {
 	// Exec executes a command through the command-line interface.
 	Exec(string) error
 
+	// ExecCount executes a command with a count prefix, as if typed before a key sequence.
+	ExecCount(command string, count int) error
+
 	// History returns a list with all tracks played back during the current session.
 	History() list.List
 
+	// Macros returns the macro registers.
+	Macros() *macro.Registers
+
 	// Return the global multibar instance.
 	Multibar() *multibar.Multibar
 
 	// Library returns a list of entry points to the Spotify library.
 	Library() *spotify_library.List
 
+	// Likes returns the liked status of tracks that is known so far.
+	Likes() *spotify_likes.Cache
+
 	// List returns the active list.
 	List() list.List
 
+	// NowPlaying returns a list with details about the currently playing track.
+	NowPlaying() *spotify_nowplaying.List
+
 	// PlayerStatus returns the current MPD player status.
 	PlayerStatus() player.State
 
 	// Quit shuts down PMS.
 	Quit()
 
+	// Repeat runs the last command that changed a list again.
+	// If count is zero, the count given to the original command is used.
+	Repeat(count int) error
+
 	// Sequencer returns a pointer to the key sequencer that receives key events.
 	Sequencer() *keys.Sequencer
 
@@ -72,6 +92,9 @@ type API interface {
 	// Styles returns the current stylesheet.
 	Styles() style.Stylesheet
 
+	// StyleRules returns the rules for styling table rows by their contents.
+	StyleRules() *stylerule.Rules
+
 	// UI returns the global UI object.
 	UI() UI
 }
@@ -24,7 +24,19 @@ type TableWidget interface {
 	Size() (int, int)
 }
 
+// Panes is a set of table widgets shown on screen at the same time.
+// Commands act on the table widget in the focused pane.
+type Panes interface {
+	Close() error
+	Focus() int
+	Only()
+	SetFocus(int) error
+	Split(vertical bool)
+	TableWidgets() []TableWidget
+}
+
 type UI interface {
+	Panes() Panes
 	Refresh()
 	TableWidget() TableWidget
 }
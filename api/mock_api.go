@@ -10,6 +10,8 @@ import (
 
 	list "github.com/ambientsound/visp/list"
 
+	macro "github.com/ambientsound/visp/pkg/macro"
+
 	mock "github.com/stretchr/testify/mock"
 
 	multibar "github.com/ambientsound/visp/multibar"
@@ -22,7 +24,13 @@ import (
 
 	spotify_library "github.com/ambientsound/visp/spotify/library"
 
+	spotify_likes "github.com/ambientsound/visp/spotify/likes"
+
+	spotify_nowplaying "github.com/ambientsound/visp/spotify/nowplaying"
+
 	style "github.com/ambientsound/visp/style"
+
+	stylerule "github.com/ambientsound/visp/pkg/stylerule"
 )
 
 // MockAPI is an autogenerated mock type for the API type
@@ -95,6 +103,20 @@ func (_m *MockAPI) Exec(_a0 string) error {
 	return r0
 }
 
+// ExecCount provides a mock function with given fields: command, count
+func (_m *MockAPI) ExecCount(command string, count int) error {
+	ret := _m.Called(command, count)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(command, count)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // History provides a mock function with given fields:
 func (_m *MockAPI) History() list.List {
 	ret := _m.Called()
@@ -127,6 +149,22 @@ func (_m *MockAPI) Library() *spotify_library.List {
 	return r0
 }
 
+// Likes provides a mock function with given fields:
+func (_m *MockAPI) Likes() *spotify_likes.Cache {
+	ret := _m.Called()
+
+	var r0 *spotify_likes.Cache
+	if rf, ok := ret.Get(0).(func() *spotify_likes.Cache); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify_likes.Cache)
+		}
+	}
+
+	return r0
+}
+
 // List provides a mock function with given fields:
 func (_m *MockAPI) List() list.List {
 	ret := _m.Called()
@@ -143,6 +181,22 @@ func (_m *MockAPI) List() list.List {
 	return r0
 }
 
+// Macros provides a mock function with given fields:
+func (_m *MockAPI) Macros() *macro.Registers {
+	ret := _m.Called()
+
+	var r0 *macro.Registers
+	if rf, ok := ret.Get(0).(func() *macro.Registers); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*macro.Registers)
+		}
+	}
+
+	return r0
+}
+
 // Multibar provides a mock function with given fields:
 func (_m *MockAPI) Multibar() *multibar.Multibar {
 	ret := _m.Called()
@@ -159,6 +213,22 @@ func (_m *MockAPI) Multibar() *multibar.Multibar {
 	return r0
 }
 
+// NowPlaying provides a mock function with given fields:
+func (_m *MockAPI) NowPlaying() *spotify_nowplaying.List {
+	ret := _m.Called()
+
+	var r0 *spotify_nowplaying.List
+	if rf, ok := ret.Get(0).(func() *spotify_nowplaying.List); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*spotify_nowplaying.List)
+		}
+	}
+
+	return r0
+}
+
 // PlayerStatus provides a mock function with given fields:
 func (_m *MockAPI) PlayerStatus() player.State {
 	ret := _m.Called()
@@ -178,6 +248,20 @@ func (_m *MockAPI) Quit() {
 	_m.Called()
 }
 
+// Repeat provides a mock function with given fields: count
+func (_m *MockAPI) Repeat(count int) error {
+	ret := _m.Called(count)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(count)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Sequencer provides a mock function with given fields:
 func (_m *MockAPI) Sequencer() *keys.Sequencer {
 	ret := _m.Called()
@@ -238,6 +322,22 @@ func (_m *MockAPI) Styles() style.Stylesheet {
 	return r0
 }
 
+// StyleRules provides a mock function with given fields:
+func (_m *MockAPI) StyleRules() *stylerule.Rules {
+	ret := _m.Called()
+
+	var r0 *stylerule.Rules
+	if rf, ok := ret.Get(0).(func() *stylerule.Rules); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stylerule.Rules)
+		}
+	}
+
+	return r0
+}
+
 // UI provides a mock function with given fields:
 func (_m *MockAPI) UI() UI {
 	ret := _m.Called()
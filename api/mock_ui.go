@@ -9,6 +9,22 @@ type MockUI struct {
 	mock.Mock
 }
 
+// Panes provides a mock function with given fields:
+func (_m *MockUI) Panes() Panes {
+	ret := _m.Called()
+
+	var r0 Panes
+	if rf, ok := ret.Get(0).(func() Panes); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Panes)
+		}
+	}
+
+	return r0
+}
+
 // Refresh provides a mock function with given fields:
 func (_m *MockUI) Refresh() {
 	_m.Called()
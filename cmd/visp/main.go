@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -12,15 +15,20 @@ import (
 	"syscall"
 
 	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/control"
 	"github.com/ambientsound/visp/prog"
 	"github.com/ambientsound/visp/tokencache"
 	"github.com/ambientsound/visp/version"
 	"github.com/ambientsound/visp/widgets"
 	"github.com/ambientsound/visp/xdg"
+	"github.com/google/uuid"
 )
 
 const (
 	ConfigFileName = "visp.conf"
+	MacroFileName  = "macros.json"
+	ScriptDirName  = "scripts"
 	TokenFileName  = "token.json"
 )
 
@@ -28,8 +36,32 @@ const (
 	ExitSuccess = iota
 	ExitInternalError
 	ExitPanic
+	ExitCommandError
 )
 
+var (
+	batchFile     = flag.String("batch", "", "run commands from a script file without a user interface, and exit")
+	clientCommand = flag.String("c", "", "send a command to a running Visp instance, and exit")
+	socketPath    = flag.String("socket", "", "path to the control socket")
+	evalCommands  commandList
+)
+
+func init() {
+	flag.Var(&evalCommands, "e", "run a command without a user interface, and exit; may be given multiple times")
+}
+
+// commandList collects repeated command-line flags.
+type commandList []string
+
+func (c *commandList) String() string {
+	return strings.Join(*c, "\n")
+}
+
+func (c *commandList) Set(value string) error {
+	*c = append(*c, value)
+	return nil
+}
+
 func logAndStderr(line string) {
 	log.Errorf(line)
 	fmt.Fprintln(os.Stderr, line)
@@ -60,6 +92,12 @@ func main() {
 
 //goland:noinspection GoUnhandledErrorResult
 func run() (int, error) {
+	flag.Parse()
+
+	if len(*clientCommand) > 0 {
+		return runClient(*clientCommand)
+	}
+
 	log.Infof("%s %s starting up", version.Program, version.Version)
 	log.Infof("This program was compiled on %s", version.BuildDate().String())
 
@@ -68,19 +106,26 @@ func run() (int, error) {
 
 	visp := &prog.Visp{}
 
-	ui, err := widgets.NewApplication(visp)
-	if err != nil {
-		return ExitInternalError, err
-	}
+	// Batch mode runs without a terminal, so errors must go to stderr.
+	batch := len(*batchFile) > 0 || len(evalCommands) > 0
+	if batch {
+		log.SetConsole(nil, os.Stderr, log.ErrorLevel)
+	} else {
+		ui, err := widgets.NewApplication(visp)
+		if err != nil {
+			return ExitInternalError, err
+		}
 
-	ui.Init()
-	defer ui.Finish()
-	go ui.Poll()
+		ui.Init()
+		defer ui.Finish()
+		go ui.Poll()
+
+		visp.Termui = ui
+	}
 
-	visp.Termui = ui
 	visp.Init()
 
-	err = visp.SourceDefaultConfig()
+	err := visp.SourceDefaultConfig()
 	if err != nil {
 		return ExitInternalError, fmt.Errorf("read default configuration: %s", err)
 	}
@@ -92,6 +137,15 @@ func run() (int, error) {
 		log.Errorf("Unable to create configuration directory: %s", err)
 	}
 
+	// Restore macros recorded in previous sessions.
+	visp.LoadMacros(filepath.Join(xdg.DataDirectory(), MacroFileName))
+
+	// Load user-defined commands from all XDG standard directories,
+	// so that they can be used in configuration files.
+	for _, dir := range xdg.ConfigDirectories() {
+		visp.LoadScripts(filepath.Join(dir, ScriptDirName))
+	}
+
 	// Source configuration files from all XDG standard directories.
 	for _, dir := range xdg.ConfigDirectories() {
 		configFile := filepath.Join(dir, ConfigFileName)
@@ -130,6 +184,35 @@ func run() (int, error) {
 		cancel()
 	}()
 
+	if batch {
+		return runBatch(ctx, visp)
+	}
+
+	// Start the control socket, so that external programs can send commands.
+	err = visp.ListenControl(controlSocketPath())
+	if err != nil {
+		log.Errorf("Unable to start control socket: %s", err)
+	}
+	defer visp.CloseControl()
+
+	// Let desktop media keys and widgets control playback.
+	if options.GetBool(options.Mpris) {
+		err = visp.ConnectMpris()
+		if err != nil {
+			log.Infof("MPRIS support disabled: unable to connect to D-Bus: %s", err)
+		}
+		defer visp.CloseMpris()
+	}
+
+	// Let remote clients control playback over HTTP.
+	if options.GetBool(options.HTTPAPI) {
+		err = visp.ListenHTTP(options.GetString(options.HTTPAPIAddress), httpToken())
+		if err != nil {
+			log.Errorf("Unable to start HTTP API: %s", err)
+		}
+		defer visp.CloseHTTP()
+	}
+
 	log.Infof("Ready.")
 
 	err = visp.Main(ctx)
@@ -139,3 +222,78 @@ func run() (int, error) {
 
 	return ExitSuccess, nil
 }
+
+// controlSocketPath returns the socket path given on the command line,
+// falling back to the `socket` option and the default path.
+func controlSocketPath() string {
+	if len(*socketPath) > 0 {
+		return *socketPath
+	}
+	if path := options.GetString(options.Socket); len(path) > 0 {
+		return path
+	}
+	return control.DefaultPath()
+}
+
+// httpToken returns the token that HTTP API clients must authenticate with.
+// If no token is configured, a random token is generated for this session.
+func httpToken() string {
+	if token := options.GetString(options.HTTPAPIToken); len(token) > 0 {
+		return token
+	}
+	token := uuid.New().String()
+	log.Infof("HTTP API token for this session: %s", token)
+	return token
+}
+
+// runBatch runs the script given on the command line, followed by any commands given with -e.
+func runBatch(ctx context.Context, visp *prog.Visp) (int, error) {
+	var script io.Reader = strings.NewReader(evalCommands.String())
+
+	if len(*batchFile) > 0 {
+		file, err := os.Open(*batchFile)
+		if err != nil {
+			return ExitInternalError, err
+		}
+		defer file.Close()
+		script = io.MultiReader(file, strings.NewReader("\n"), script)
+	}
+
+	log.SetConsole(os.Stdout, os.Stderr, log.InfoLevel)
+
+	err := visp.Batch(ctx, script)
+	if err != nil {
+		return ExitCommandError, err
+	}
+
+	return ExitSuccess, nil
+}
+
+// runClient sends a single command to a running Visp instance and prints the result.
+// Configuration files are not read in client mode, so only the -socket flag
+// and the default path are used to find the control socket.
+func runClient(command string) (int, error) {
+	path := *socketPath
+	if len(path) == 0 {
+		path = control.DefaultPath()
+	}
+	resp, err := control.Send(path, command)
+	if err != nil {
+		return ExitInternalError, err
+	}
+
+	if len(resp.Error) > 0 {
+		return ExitCommandError, errors.New(resp.Error)
+	}
+
+	if resp.Result != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(resp.Result)
+		if err != nil {
+			return ExitInternalError, err
+		}
+	}
+
+	return ExitSuccess, nil
+}
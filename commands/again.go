@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"github.com/ambientsound/visp/api"
+)
+
+// Again repeats the last command that changed a list.
+type Again struct {
+	command
+	api   api.API
+	count int
+}
+
+// NewAgain returns Again.
+func NewAgain(api api.API) Command {
+	return &Again{
+		api: api,
+	}
+}
+
+// Parse implements Command.
+func (cmd *Again) Parse() error {
+	return cmd.ParseEnd()
+}
+
+// SetCount implements Counter. The count replaces the count of the repeated command.
+func (cmd *Again) SetCount(count int) {
+	cmd.count = count
+}
+
+// Exec implements Command.
+func (cmd *Again) Exec() error {
+	return cmd.api.Repeat(cmd.count)
+}
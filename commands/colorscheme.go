@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/pkg/colorscheme"
+	"github.com/ambientsound/visp/style"
+)
+
+// Colorscheme loads a set of styles from a colorscheme file.
+type Colorscheme struct {
+	command
+	api  api.API
+	name string
+}
+
+// NewColorscheme returns Colorscheme.
+func NewColorscheme(api api.API) Command {
+	return &Colorscheme{
+		api: api,
+	}
+}
+
+// Parse implements Command.
+func (cmd *Colorscheme) Parse() error {
+	names := colorscheme.Names(colorscheme.Dirs())
+
+	// Scheme names may contain characters that the lexer splits on,
+	// so everything up to the next whitespace is part of the name.
+	tok, lit := cmd.ScanIgnoreWhitespace()
+	for tok != lexer.TokenEnd && tok != lexer.TokenWhitespace && tok != lexer.TokenComment {
+		cmd.name += lit
+		tok, lit = cmd.Scan()
+	}
+	cmd.setTabComplete(cmd.name, names)
+
+	if len(cmd.name) == 0 {
+		return fmt.Errorf("unexpected END, expected colorscheme name")
+	}
+
+	if tok == lexer.TokenWhitespace {
+		cmd.setTabCompleteEmpty()
+		return cmd.ParseEnd()
+	}
+
+	return nil
+}
+
+// Exec implements Command.
+func (cmd *Colorscheme) Exec() error {
+	file, err := colorscheme.Open(cmd.name, colorscheme.Dirs())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	sheet, err := cmd.read(file)
+	if err != nil {
+		return fmt.Errorf("colorscheme '%s': %s", cmd.name, err)
+	}
+
+	// All styles are parsed before any of them are applied,
+	// so that errors in the colorscheme file leave the current styles intact.
+	styles := cmd.api.Styles()
+	for key, value := range sheet {
+		styles[key] = value
+	}
+
+	log.Infof("Loaded colorscheme '%s'", cmd.name)
+	cmd.api.UI().Refresh()
+
+	return nil
+}
+
+// read parses the style lines of a colorscheme file.
+func (cmd *Colorscheme) read(reader io.Reader) (style.Stylesheet, error) {
+	sheet := make(style.Stylesheet)
+	scanner := bufio.NewScanner(reader)
+
+	for n := 1; scanner.Scan(); n++ {
+		lex := lexer.NewScanner(strings.NewReader(scanner.Text()))
+
+		tok, verb := lex.ScanIgnoreWhitespace()
+		switch {
+		case tok == lexer.TokenEnd, tok == lexer.TokenComment:
+			continue
+		case tok != lexer.TokenIdentifier || verb != "style":
+			return nil, fmt.Errorf("line %d: unexpected '%s', expected 'style'", n, verb)
+		}
+
+		st := NewStyle(cmd.api).(*Style)
+		st.SetScanner(lex)
+		err := st.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n, err)
+		}
+
+		sheet[st.styleKey] = st.styleValue
+	}
+
+	return sheet, scanner.Err()
+}
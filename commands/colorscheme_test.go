@@ -0,0 +1,82 @@
+package commands_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/pkg/colorscheme"
+	"github.com/ambientsound/visp/style"
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var colorschemeTests = []commands.Test{
+	// Valid forms
+	{`nord`, true, nil, nil, []string{"nord"}},
+	{`gruvbox `, true, nil, nil, []string{}},
+	{`so`, true, nil, nil, []string{"solarized"}},
+
+	// Invalid forms
+	{``, false, nil, nil, nil},
+	{`nord gruvbox`, false, nil, nil, nil},
+
+	// Styles are applied and the screen is redrawn
+	{`nord`, true, setupTestColorscheme, testColorschemeNord, nil},
+	{`nonexist`, true, setupTestColorscheme, testColorschemeMissing, nil},
+	{`broken`, true, setupTestColorschemeFile, testColorschemeBroken, nil},
+}
+
+func TestColorscheme(t *testing.T) {
+	commands.TestVerb(t, "colorscheme", colorschemeTests)
+}
+
+func setupTestColorscheme(data *commands.TestData) {
+	ui := &api.MockUI{}
+	ui.On("Refresh").Return()
+	data.MockAPI.On("UI").Return(ui)
+	data.MockAPI.On("Styles").Return(style.Stylesheet{
+		"artist": tcell.StyleDefault,
+		"custom": tcell.StyleDefault.Bold(true),
+	})
+}
+
+// setupTestColorschemeFile writes a colorscheme that fails on its second line.
+func setupTestColorschemeFile(data *commands.TestData) {
+	setupTestColorscheme(data)
+	dir, err := ioutil.TempDir("", "visp-colors")
+	require.NoError(data.T, err)
+	colors := filepath.Join(dir, "visp", colorscheme.DirName)
+	require.NoError(data.T, os.MkdirAll(colors, 0755))
+	require.NoError(data.T, ioutil.WriteFile(filepath.Join(colors, "broken.conf"), []byte("style artist red\nbind global x quit\n"), 0644))
+	require.NoError(data.T, os.Setenv("XDG_CONFIG_HOME", dir))
+	data.T.Cleanup(func() {
+		os.Unsetenv("XDG_CONFIG_HOME")
+		os.RemoveAll(dir)
+	})
+}
+
+func testColorschemeNord(data *commands.TestData) {
+	assert.NoError(data.T, data.Cmd.Exec())
+	styles := data.Api.Styles()
+	assert.Equal(data.T, tcell.StyleDefault.Foreground(tcell.GetColor("#ebcb8b")), styles["artist"])
+	assert.Equal(data.T, tcell.StyleDefault.Bold(true), styles["custom"])
+	data.Api.UI().(*api.MockUI).AssertCalled(data.T, "Refresh")
+}
+
+func testColorschemeMissing(data *commands.TestData) {
+	assert.Error(data.T, data.Cmd.Exec())
+	assert.Equal(data.T, tcell.StyleDefault, data.Api.Styles()["artist"])
+	data.Api.UI().(*api.MockUI).AssertNotCalled(data.T, "Refresh")
+}
+
+func testColorschemeBroken(data *commands.TestData) {
+	err := data.Cmd.Exec()
+	assert.Error(data.T, err)
+	assert.Contains(data.T, err.Error(), "line 2")
+	assert.Equal(data.T, tcell.StyleDefault, data.Api.Styles()["artist"])
+}
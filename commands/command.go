@@ -23,6 +23,7 @@ const (
 	ClipboardsContext = "clipboards"
 	DevicesContext    = "devices"
 	GlobalContext     = "global"
+	HelpContext       = "help"
 	LibraryContext    = "library"
 	PlaylistsContext  = "playlists"
 	TracklistContext  = "tracklist"
@@ -35,6 +36,7 @@ var contexts = []string{
 	ClipboardsContext,
 	DevicesContext,
 	GlobalContext,
+	HelpContext,
 	LibraryContext,
 	PlaylistsContext,
 	TracklistContext,
@@ -44,46 +46,54 @@ var contexts = []string{
 // Verbs contain mappings from strings to Command constructors.
 // Make sure to add commands here when implementing them.
 var Verbs = map[string]func(api.API) Command{
-	"add":       NewAdd,
-	"auth":      NewAuth,
-	"bind":      NewBind,
-	"columns":   NewColumns,
-	"copy":      NewYank,
-	"cursor":    NewCursor,
-	"cut":       NewCut,
-	"device":    NewDevice,
-	"inputmode": NewInputMode,
-	"isolate":   NewIsolate,
-	"like":      NewLike,
-	"list":      NewList,
-	"next":      NewNext,
-	"paste":     NewPaste,
-	"pause":     NewPause,
-	"play":      NewPlay,
-	"previous":  NewPrevious,
-	"prev":      NewPrevious,
-	"print":     NewPrint,
-	"q":         NewQuit,
-	"quit":      NewQuit,
-	"recommend": NewRecommend,
-	"redraw":    NewRedraw,
-	"rename":    NewRename,
-	"repeat":    NewRepeat,
-	"seek":      NewSeek,
-	"select":    NewSelect,
-	"se":        NewSet,
-	"set":       NewSet,
-	"show":      NewShow,
-	"shuffle":   NewShuffle,
-	"sort":      NewSort,
-	"stop":      NewStop,
-	"style":     NewStyle,
-	"unbind":    NewUnbind,
-	"viewport":  NewViewport,
-	"volume":    NewVolume,
-	"w":         NewWrite,
-	"write":     NewWrite,
-	"yank":      NewYank,
+	"add":         NewAdd,
+	"again":       NewAgain,
+	"auth":        NewAuth,
+	"bind":        NewBind,
+	"colo":        NewColorscheme,
+	"colorscheme": NewColorscheme,
+	"columns":     NewColumns,
+	"copy":        NewYank,
+	"cursor":      NewCursor,
+	"cut":         NewCut,
+	"device":      NewDevice,
+	"help":        NewHelp,
+	"inputmode":   NewInputMode,
+	"isolate":     NewIsolate,
+	"like":        NewLike,
+	"list":        NewList,
+	"macro":       NewMacro,
+	"next":        NewNext,
+	"open":        NewOpen,
+	"pane":        NewPane,
+	"paste":       NewPaste,
+	"pause":       NewPause,
+	"play":        NewPlay,
+	"previous":    NewPrevious,
+	"prev":        NewPrevious,
+	"print":       NewPrint,
+	"q":           NewQuit,
+	"quit":        NewQuit,
+	"recommend":   NewRecommend,
+	"redraw":      NewRedraw,
+	"rename":      NewRename,
+	"repeat":      NewRepeat,
+	"seek":        NewSeek,
+	"select":      NewSelect,
+	"se":          NewSet,
+	"set":         NewSet,
+	"show":        NewShow,
+	"shuffle":     NewShuffle,
+	"sort":        NewSort,
+	"stop":        NewStop,
+	"style":       NewStyle,
+	"stylerule":   NewStyleRule,
+	"unbind":      NewUnbind,
+	"viewport":    NewViewport,
+	"volume":      NewVolume,
+	"w":           NewWrite,
+	"write":       NewWrite,
+	"yank":        NewYank,
 }
 
 // Command must be implemented by all commands.
@@ -106,6 +116,14 @@ type Command interface {
 	Scanned() []parser.Token
 }
 
+// Counter is implemented by commands that make use of a count typed before
+// a key sequence, such as `5j` to move the cursor down five rows.
+// Commands that don't implement Counter are run once for each count instead.
+type Counter interface {
+	// SetCount is called after Parse and before Exec.
+	SetCount(int)
+}
+
 // command is the base class for all commands, implementing the parser and tab completion.
 type command struct {
 	parser.Parser
@@ -115,8 +133,10 @@ type command struct {
 
 func RowContext(dataType list.DataType) string {
 	switch dataType {
-	case list.DataTypeTrack:
+	case list.DataTypeTrack, list.DataTypeEpisode:
 		return TracklistContext
+	case list.DataTypeHelp:
+		return HelpContext
 	default:
 		return ""
 	}
@@ -151,6 +171,31 @@ func Contexts(a api.API) []string {
 	return ctx
 }
 
+// registered contains verbs added at runtime through Register.
+var registered = make(map[string]struct{})
+
+// Register adds a command under a new verb, such as commands defined by user scripts.
+// Built-in commands cannot be replaced, but verbs added by Register can be registered again.
+func Register(verb string, ctor func(api.API) Command, desc Description) error {
+	if _, ok := registered[verb]; !ok && Verbs[verb] != nil {
+		return fmt.Errorf("cannot redefine built-in command '%s'", verb)
+	}
+	registered[verb] = struct{}{}
+	Verbs[verb] = ctor
+	Descriptions[verb] = desc
+	return nil
+}
+
+// Unregister removes a verb added by Register. Built-in commands are left alone.
+func Unregister(verb string) {
+	if _, ok := registered[verb]; !ok {
+		return
+	}
+	delete(registered, verb)
+	delete(Verbs, verb)
+	delete(Descriptions, verb)
+}
+
 // New returns the Command associated with the given verb.
 func New(verb string, a api.API) Command {
 	ctor := Verbs[verb]
@@ -170,6 +215,22 @@ func Keys() []string {
 	return keys
 }
 
+// selectCount selects count rows starting at the cursor,
+// unless some rows are already selected.
+func selectCount(lst list.List, count int) {
+	if count <= 1 {
+		return
+	}
+	for i := 0; i < lst.Len(); i++ {
+		if lst.Selected(i) {
+			return
+		}
+	}
+	for i := lst.Cursor(); i < lst.Cursor()+count && lst.InRange(i); i++ {
+		lst.SetSelected(i, true)
+	}
+}
+
 // setTabComplete defines a string slice that will be used for tab completion
 // at the current point in parsing.
 func (c *command) setTabComplete(filter string, s []string) {
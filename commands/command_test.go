@@ -0,0 +1,45 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	ctor := func(a api.API) commands.Command {
+		return commands.NewRedraw(a)
+	}
+
+	desc := commands.Description{Summary: "Redraw, but custom."}
+
+	assert.Error(t, commands.Register("play", ctor, commands.Description{}))
+
+	t.Cleanup(func() {
+		commands.Unregister("custom-redraw")
+	})
+	assert.NoError(t, commands.Register("custom-redraw", ctor, desc))
+	assert.NoError(t, commands.Register("custom-redraw", ctor, desc))
+	assert.Contains(t, commands.Keys(), "custom-redraw")
+	assert.Equal(t, desc, commands.Describe("custom-redraw"))
+}
+
+func TestUnregister(t *testing.T) {
+	ctor := func(a api.API) commands.Command {
+		return commands.NewRedraw(a)
+	}
+
+	assert.NoError(t, commands.Register("custom-redraw", ctor, commands.Description{}))
+	commands.Unregister("custom-redraw")
+	assert.NotContains(t, commands.Keys(), "custom-redraw")
+	assert.NotContains(t, commands.Descriptions, "custom-redraw")
+
+	// Built-in commands can't be removed, and verbs that are no longer
+	// registered can't be redefined as if they were built-in.
+	commands.Unregister("play")
+	assert.Contains(t, commands.Keys(), "play")
+	assert.NoError(t, commands.Register("custom-redraw", ctor, commands.Description{}))
+	commands.Unregister("custom-redraw")
+}
@@ -19,10 +19,12 @@ type Cursor struct {
 	command
 	api             api.API
 	absolute        int
+	count           int
 	current         bool
 	finished        bool
 	list            list.List
 	nextOfDirection int
+	line            bool
 	nextOfTags      []string
 	relative        int
 }
@@ -68,10 +70,13 @@ func (cmd *Cursor) Parse() error {
 		cmd.relative = -1
 	case "down":
 		cmd.relative = 1
-	case "home":
-		cmd.absolute = 0
-	case "end":
-		cmd.absolute = cmd.list.Len() - 1
+	case "home", "end":
+		cmd.line = true
+		if lit == "home" {
+			cmd.absolute = 0
+		} else {
+			cmd.absolute = cmd.list.Len() - 1
+		}
 	case "high":
 		ymin, _ := tableWidget.GetVisibleBoundaries()
 		cmd.absolute = ymin
@@ -104,10 +109,28 @@ func (cmd *Cursor) Parse() error {
 	return cmd.ParseEnd()
 }
 
+// SetCount implements Counter. Relative movement is multiplied by the count, and
+// `cursor home` and `cursor end` move to the row with that number, as in Vim.
+func (cmd *Cursor) SetCount(count int) {
+	cmd.count = count
+}
+
 // Exec implements Command
 func (cmd *Cursor) Exec() error {
+	if cmd.count > 0 {
+		switch {
+		case cmd.line:
+			cmd.absolute = cmd.count - 1
+		case cmd.relative != 0:
+			cmd.relative *= cmd.count
+		}
+	}
+
 	switch {
 	case cmd.nextOfDirection != 0:
+		for i := 1; i < cmd.count; i++ {
+			cmd.list.SetCursor(cmd.runNextOf())
+		}
 		cmd.absolute = cmd.runNextOf()
 
 	case cmd.current:
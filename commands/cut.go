@@ -11,8 +11,9 @@ import (
 // Cut removes songs from songlists.
 type Cut struct {
 	command
-	api  api.API
-	list list.List
+	api   api.API
+	count int
+	list  list.List
 }
 
 // NewCut returns Cut.
@@ -28,8 +29,14 @@ func (cmd *Cut) Parse() error {
 	return cmd.ParseEnd()
 }
 
+// SetCount implements Counter. If nothing is selected, count rows are cut, starting at the cursor.
+func (cmd *Cut) SetCount(count int) {
+	cmd.count = count
+}
+
 // Exec implements Command.
 func (cmd *Cut) Exec() error {
+	selectCount(cmd.list, cmd.count)
 
 	selection := cmd.list.Selection()
 	indices := cmd.list.SelectionIndices()
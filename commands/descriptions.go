@@ -0,0 +1,256 @@
+package commands
+
+// Description documents a command in the built-in help window.
+type Description struct {
+	// Summary is a one-line description of what the command does.
+	Summary string
+
+	// Usage lists every form of the command, in the same notation as the documentation:
+	// placeholders are enclosed in <angle brackets>, optional parameters in [square brackets].
+	Usage []string
+
+	// Alias is set if this verb is a shorthand for another verb.
+	Alias string
+
+	// Changes is set if the command changes a list, and can be repeated with `again`.
+	Changes bool
+}
+
+// Descriptions contain help texts for the verbs in Verbs.
+// Make sure to add a description here when implementing a new command.
+var Descriptions = map[string]Description{
+	"add": {
+		Changes: true,
+		Summary: "Add tracks to the playback queue.",
+		Usage:   []string{"add", "add <uri> [<uri> [...]]"},
+	},
+	"again": {
+		Summary: "Repeat the last command that changed a list.",
+		Usage:   []string{"again"},
+	},
+	"auth": {
+		Summary: "Authenticate with Spotify, optionally using a token from the authentication web page.",
+		Usage:   []string{"auth", "auth <token>"},
+	},
+	"bind": {
+		Summary: "Bind a key sequence to a command.",
+		Usage:   []string{"bind <context> <key sequence> <command>"},
+	},
+	"colo": {
+		Alias: "colorscheme",
+	},
+	"colorscheme": {
+		Summary: "Load a set of styles from a colorscheme file.",
+		Usage:   []string{"colorscheme <name>"},
+	},
+	"columns": {
+		Changes: true,
+		Summary: "Set which columns are visible in the current list.",
+		Usage:   []string{"columns <column> [<column> [...]]"},
+	},
+	"copy": {
+		Alias: "yank",
+	},
+	"cursor": {
+		Summary: "Move the cursor.",
+		Usage: []string{
+			"cursor up", "cursor down",
+			"cursor home", "cursor end",
+			"cursor high", "cursor middle", "cursor low",
+			"cursor current", "cursor random",
+			"cursor nextOf <tag> [<tag> [...]]", "cursor prevOf <tag> [<tag> [...]]",
+			"cursor +<N>", "cursor -<N>", "cursor <N>",
+		},
+	},
+	"cut": {
+		Changes: true,
+		Summary: "Remove the selection from the list, and put the removed tracks on the clipboard.",
+		Usage:   []string{"cut"},
+	},
+	"device": {
+		Summary: "Transfer playback to the device under the cursor, or to the given device.",
+		Usage:   []string{"device activate", "device activate <id>"},
+	},
+	"help": {
+		Summary: "Show help for commands, options, and key bindings.",
+		Usage:   []string{"help", "help <command>", "help <search term>"},
+	},
+	"inputmode": {
+		Summary: "Switch between normal mode, command input, and search.",
+		Usage:   []string{"inputmode normal", "inputmode input", "inputmode search"},
+	},
+	"isolate": {
+		Summary: "Search for tracks with the same tags as the selection, and show them in a new list.",
+		Usage:   []string{"isolate <tag> [<tag> [...]]"},
+	},
+	"like": {
+		Changes: true,
+		Summary: "Add or remove tracks from the library of liked songs.",
+		Usage: []string{
+			"like add cursor", "like add current", "like add selection",
+			"like remove cursor", "like remove current", "like remove selection",
+			"like toggle cursor", "like toggle current", "like toggle selection",
+		},
+	},
+	"list": {
+		Summary: "Switch between, create, and close lists.",
+		Usage: []string{
+			"list next", "list prev", "list home", "list last", "list <N>",
+			"list goto <id>|<url>", "list open", "list new [<name>]", "list duplicate", "list close",
+		},
+	},
+	"macro": {
+		Summary: "Record commands into a register, and play them back.",
+		Usage:   []string{"macro record [<register>]", "macro stop", "macro play [<register>|@]"},
+	},
+	"next": {
+		Summary: "Skip to the next track.",
+		Usage:   []string{"next"},
+	},
+	"open": {
+		Summary: "Open a Spotify URL or URI.",
+		Usage:   []string{"open <url>", "open <uri>"},
+	},
+	"pane": {
+		Summary: "Split the screen into panes, and move between them.",
+		Usage:   []string{"pane split", "pane vsplit", "pane close", "pane only", "pane next", "pane prev", "pane <N>"},
+	},
+	"paste": {
+		Changes: true,
+		Summary: "Insert the clipboard contents after or before the cursor.",
+		Usage:   []string{"paste [after] [other]", "paste before [other]", "paste to other"},
+	},
+	"pause": {
+		Summary: "Pause or resume playback.",
+		Usage:   []string{"pause"},
+	},
+	"play": {
+		Summary: "Start playback of the queue, the list from the cursor, or the selection.",
+		Usage:   []string{"play", "play cursor", "play selection"},
+	},
+	"prev": {
+		Alias: "previous",
+	},
+	"previous": {
+		Summary: "Skip back to the previous track.",
+		Usage:   []string{"prev[ious]"},
+	},
+	"print": {
+		Summary: "Show tags of the row under the cursor.",
+		Usage:   []string{"print [<tag> [...]]"},
+	},
+	"q": {
+		Alias: "quit",
+	},
+	"quit": {
+		Summary: "Exit the program. Unsaved changes are lost.",
+		Usage:   []string{"q[uit]"},
+	},
+	"recommend": {
+		Summary: "Get track recommendations based on the selection.",
+		Usage: []string{
+			"recommend",
+			"recommend artist [<attr>=<TARGET|MIN-MAX> [...]]",
+			"recommend track [<attr>=<TARGET|MIN-MAX> [...]]",
+		},
+	},
+	"redraw": {
+		Summary: "Force a screen redraw.",
+		Usage:   []string{"redraw"},
+	},
+	"rename": {
+		Changes: true,
+		Summary: "Rename the current playlist.",
+		Usage:   []string{"rename <name>"},
+	},
+	"repeat": {
+		Summary: "Switch between repeat modes.",
+		Usage:   []string{"repeat", "repeat context", "repeat track", "repeat off"},
+	},
+	"seek": {
+		Summary: "Seek in the current track, in seconds.",
+		Usage:   []string{"seek <N>", "seek +<N>", "seek -<N>"},
+	},
+	"select": {
+		Summary: "Manipulate the selection.",
+		Usage: []string{
+			"select toggle", "select visual", "select duplicates",
+			"select nearby <tag> [<tag> [...]]", "select intersect <list>",
+		},
+	},
+	"se": {
+		Alias: "set",
+	},
+	"set": {
+		Summary: "Change or query options.",
+		Usage: []string{
+			"set <option>=<value>", "set <option>", "set no<option>",
+			"set inv<option>", "set <option>!", "set <option>?",
+		},
+	},
+	"show": {
+		Summary: "Switch to a special window.",
+		Usage: []string{
+			"show clipboards", "show history", "show keybindings",
+			"show library", "show logs", "show nowplaying", "show selected", "show windows",
+		},
+	},
+	"shuffle": {
+		Summary: "Switch shuffle on or off.",
+		Usage:   []string{"shuffle", "shuffle on", "shuffle off"},
+	},
+	"sort": {
+		Changes: true,
+		Summary: "Sort the current list.",
+		Usage:   []string{"sort [<tag> [...]]"},
+	},
+	"stop": {
+		Summary: "Stop playback.",
+		Usage:   []string{"stop"},
+	},
+	"style": {
+		Summary: "Set the color and text attributes of a UI item.",
+		Usage:   []string{"style <name> [<foreground> [<background>]] [bold] [underline] [reverse] [blink] [strikethrough]"},
+	},
+	"stylerule": {
+		Summary: "Style table rows that match a predicate on their fields.",
+		Usage:   []string{"stylerule <name> <field>[<operator><value>]", "stylerule <name> !<field>", "stylerule clear", "stylerule"},
+	},
+	"unbind": {
+		Summary: "Remove a key binding.",
+		Usage:   []string{"unbind <context> <key sequence>"},
+	},
+	"viewport": {
+		Summary: "Scroll the viewport.",
+		Usage: []string{
+			"viewport up", "viewport down",
+			"viewport halfpageup", "viewport halfpagedown",
+			"viewport pageup", "viewport pagedown",
+			"viewport high", "viewport middle", "viewport low",
+		},
+	},
+	"volume": {
+		Summary: "Set or adjust the volume.",
+		Usage:   []string{"volume <N>", "volume +<N>", "volume -<N>", "volume mute"},
+	},
+	"w": {
+		Alias: "write",
+	},
+	"write": {
+		Summary: "Save the current list as a Spotify playlist.",
+		Usage:   []string{"w[rite] [<name>]"},
+	},
+	"yank": {
+		Summary: "Copy the selection to the clipboard.",
+		Usage:   []string{"yank", "yank current", "yank other", "yank url|uri|text [current]", "copy"},
+	},
+}
+
+// Describe returns the description of a verb, following aliases.
+func Describe(verb string) Description {
+	desc := Descriptions[verb]
+	if len(desc.Alias) > 0 {
+		return Describe(desc.Alias)
+	}
+	return desc
+}
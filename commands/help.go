@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/options"
+)
+
+// Sections of the help window.
+const (
+	HelpSectionCommand    = "command"
+	HelpSectionKeyBinding = "keybinding"
+	HelpSectionOption     = "option"
+	HelpSectionUsage      = "usage"
+)
+
+var helpColumns = []string{"section", "name", "value", "description"}
+
+// Help shows a list of commands, options, and key bindings.
+type Help struct {
+	command
+	api  api.API
+	term string
+}
+
+// NewHelp returns Help.
+func NewHelp(api api.API) Command {
+	return &Help{
+		api: api,
+	}
+}
+
+// Parse implements Command.
+func (cmd *Help) Parse() error {
+	tok, lit := cmd.ScanIgnoreWhitespace()
+	cmd.setTabComplete(lit, Keys())
+
+	switch tok {
+	case lexer.TokenEnd:
+		return nil
+	case lexer.TokenIdentifier:
+		cmd.term = lit
+	default:
+		return fmt.Errorf("unexpected '%s', expected command or search term", lit)
+	}
+
+	return cmd.ParseEnd()
+}
+
+// Exec implements Command.
+func (cmd *Help) Exec() error {
+	var lst list.List
+
+	switch {
+	case len(cmd.term) == 0:
+		lst = HelpList(cmd.api)
+	case Verbs[cmd.term] != nil:
+		lst = VerbHelpList(cmd.api, cmd.term)
+	default:
+		lst = filterHelp(HelpList(cmd.api), cmd.term)
+		if lst.Len() == 0 {
+			return fmt.Errorf("no help for '%s'", cmd.term)
+		}
+	}
+
+	cmd.api.SetList(lst)
+
+	return nil
+}
+
+// HelpList returns a list of all commands, options, and key bindings.
+func HelpList(a api.API) list.List {
+	lst := newHelpList("help", "Help")
+
+	for _, verb := range Keys() {
+		desc := Descriptions[verb]
+		value := ""
+		if len(desc.Alias) > 0 {
+			value = "alias for " + desc.Alias
+		}
+		addHelpRow(lst, HelpSectionCommand, verb, value, Describe(verb).Summary)
+	}
+
+	keys := options.AllKeys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := options.Get(key)
+		addHelpRow(lst, HelpSectionOption, key, fmt.Sprint(value), optionType(value))
+	}
+
+	addKeyBindings(lst, a, func(string) bool { return true })
+
+	return lst
+}
+
+// VerbHelpList returns a list with all forms of a command, and the key bindings that run it.
+func VerbHelpList(a api.API, verb string) list.List {
+	desc := Describe(verb)
+	lst := newHelpList("help-"+verb, "Help: "+verb)
+
+	for i, usage := range desc.Usage {
+		summary := ""
+		if i == 0 {
+			summary = desc.Summary
+		}
+		addHelpRow(lst, HelpSectionUsage, usage, "", summary)
+	}
+
+	addKeyBindings(lst, a, func(command string) bool {
+		fields := strings.Fields(command)
+		return len(fields) > 0 && canonicalVerb(fields[0]) == canonicalVerb(verb)
+	})
+
+	return lst
+}
+
+func newHelpList(id, name string) list.List {
+	lst := list.New()
+	lst.SetID(id)
+	lst.SetName(name)
+	lst.SetVisibleColumns(helpColumns)
+	return lst
+}
+
+func addHelpRow(lst list.List, section, name, value, description string) {
+	lst.Add(list.NewRow(
+		section+":"+name,
+		list.DataTypeHelp,
+		map[string]string{
+			"section":     section,
+			"name":        name,
+			"value":       value,
+			"description": description,
+		},
+	))
+}
+
+// addKeyBindings adds all key bindings whose command matches the filter.
+func addKeyBindings(lst list.List, a api.API, match func(command string) bool) {
+	for _, row := range a.Sequencer().List().All() {
+		fields := row.Fields()
+		if !match(fields["command"]) {
+			continue
+		}
+		name := fields["context"] + " " + fields["keySequence"]
+		addHelpRow(lst, HelpSectionKeyBinding, name, fields["command"], "")
+	}
+}
+
+// filterHelp returns the rows where any field contains the search term.
+func filterHelp(src list.List, term string) list.List {
+	term = strings.ToLower(term)
+	lst := newHelpList("help-search", "Help: "+term)
+
+	for _, row := range src.All() {
+		for _, value := range row.Fields() {
+			if strings.Contains(strings.ToLower(value), term) {
+				lst.Add(row)
+				break
+			}
+		}
+	}
+
+	return lst
+}
+
+// canonicalVerb returns the verb that an alias refers to.
+func canonicalVerb(verb string) string {
+	if alias := Descriptions[verb].Alias; len(alias) > 0 {
+		return alias
+	}
+	return verb
+}
+
+func optionType(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case int:
+		return "integer"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,50 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/input/keys"
+	"github.com/ambientsound/visp/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var helpTests = []commands.Test{
+	// Valid forms
+	{``, true, initHelp, testHelpList("help"), nil},
+	{`select`, true, initHelp, testHelpList("help-select"), []string{"select"}},
+	{`volume`, true, initHelp, testHelpList("help-volume"), []string{"volume"}},
+	{`sel`, true, initHelp, testHelpList("help-search"), []string{"select"}},
+
+	// Invalid forms
+	{`select foo`, false, nil, nil, nil},
+}
+
+func initHelp(data *commands.TestData) {
+	data.MockAPI.On("Sequencer").Return(keys.NewSequencer())
+	data.MockAPI.On("SetList", mock.Anything).Return()
+}
+
+func testHelpList(id string) func(data *commands.TestData) {
+	return func(data *commands.TestData) {
+		err := data.Cmd.Exec()
+		assert.NoError(data.T, err)
+		data.MockAPI.AssertCalled(data.T, "SetList", mock.MatchedBy(func(lst list.List) bool {
+			return lst.ID() == id && lst.Len() > 0
+		}))
+	}
+}
+
+func TestHelp(t *testing.T) {
+	commands.TestVerb(t, "help", helpTests)
+}
+
+// Test that every command is documented in the help window.
+func TestDescriptions(t *testing.T) {
+	for verb := range commands.Verbs {
+		desc := commands.Describe(verb)
+		assert.NotEmpty(t, desc.Summary, "command '%s' has no description", verb)
+		assert.NotEmpty(t, desc.Usage, "command '%s' has no usage", verb)
+	}
+}
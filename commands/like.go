@@ -152,6 +152,9 @@ func (cmd *Like) Exec() error {
 		if err != nil {
 			return err
 		}
+		for _, id := range additions {
+			cmd.api.Likes().Set(id.String(), true)
+		}
 		log.Infof("%d track(s) added to Liked tracks", len(additions))
 	}
 
@@ -160,6 +163,9 @@ func (cmd *Like) Exec() error {
 		if err != nil {
 			return err
 		}
+		for _, id := range removals {
+			cmd.api.Likes().Set(id.String(), false)
+		}
 		log.Infof("%d track(s) removed from Liked tracks", len(removals))
 	}
 
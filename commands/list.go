@@ -12,6 +12,7 @@ import (
 	"github.com/ambientsound/visp/spotify/aggregator"
 	"github.com/ambientsound/visp/spotify/devices"
 	"github.com/ambientsound/visp/spotify/library"
+	"github.com/ambientsound/visp/spotify/link"
 	spotify_tracklist "github.com/ambientsound/visp/spotify/tracklist"
 	"github.com/google/uuid"
 	"github.com/zmb3/spotify/v2"
@@ -119,6 +120,9 @@ func (cmd *List) Exec() error {
 		if row == nil {
 			return fmt.Errorf("no playlist selected")
 		}
+		if lnk, err := spotify_link.Parse(string(row.URI())); err == nil {
+			return cmd.Goto(string(lnk.URI()))
+		}
 		return cmd.Goto(row.ID())
 
 	case cmd.relative != 0:
@@ -179,6 +183,9 @@ func (cmd *List) Goto(id string) error {
 		return err
 	}
 
+	// Lists opened from links place their own cursor, such as on a linked track.
+	resetCursor := true
+
 	t := time.Now()
 	switch id {
 	case spotify_library.MyPlaylists:
@@ -196,6 +203,12 @@ func (cmd *List) Goto(id string) error {
 	case spotify_library.Devices:
 		lst, err = spotify_devices.New(*cmd.client)
 	default:
+		lnk, linkErr := spotify_link.Parse(id)
+		if linkErr == nil {
+			lst, err = spotify_aggregator.Open(*cmd.client, *lnk, limit)
+			resetCursor = false
+			break
+		}
 		lst, err = spotify_aggregator.ListWithID(*cmd.client, id, limit)
 		if err != nil {
 			break
@@ -211,7 +224,9 @@ func (cmd *List) Goto(id string) error {
 	log.Infof("Loaded %s.", lst.Name())
 
 	// Reset cursor
-	lst.SetCursor(0)
+	if resetCursor {
+		lst.SetCursor(0)
+	}
 
 	cmd.api.SetList(lst)
 
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/pkg/macro"
+)
+
+// Macro records commands into registers, and plays them back.
+type Macro struct {
+	command
+	api      api.API
+	action   string
+	register string
+	count    int
+}
+
+// NewMacro returns Macro.
+func NewMacro(api api.API) Command {
+	return &Macro{
+		api: api,
+	}
+}
+
+// Parse implements Command.
+func (cmd *Macro) Parse() error {
+	tok, lit := cmd.ScanIgnoreWhitespace()
+	cmd.setTabComplete(lit, []string{"play", "record", "stop"})
+
+	if tok != lexer.TokenIdentifier {
+		return fmt.Errorf("unexpected '%s'; expected one of 'play', 'record', 'stop'", lit)
+	}
+
+	cmd.action = lit
+
+	switch cmd.action {
+	case "play", "record":
+		return cmd.parseRegister()
+	case "stop":
+		cmd.setTabCompleteEmpty()
+		return cmd.ParseEnd()
+	default:
+		return fmt.Errorf("unexpected '%s'; expected one of 'play', 'record', 'stop'", lit)
+	}
+}
+
+// parseRegister reads an optional register name. If no register is given,
+// it is read from the next key press.
+func (cmd *Macro) parseRegister() error {
+	tok, lit := cmd.ScanIgnoreWhitespace()
+	cmd.setTabComplete(lit, cmd.api.Macros().Names())
+
+	if tok == lexer.TokenEnd {
+		return nil
+	}
+
+	if !(cmd.action == "play" && lit == macro.LastRegister) {
+		if err := macro.ValidRegister(lit); err != nil {
+			return err
+		}
+	}
+	cmd.register = lit
+
+	cmd.setTabCompleteEmpty()
+	return cmd.ParseEnd()
+}
+
+// SetCount implements Counter. A macro is played back count times.
+func (cmd *Macro) SetCount(count int) {
+	cmd.count = count
+}
+
+// Exec implements Command.
+func (cmd *Macro) Exec() error {
+	registers := cmd.api.Macros()
+
+	switch cmd.action {
+	case "stop":
+		return cmd.stop()
+
+	case "record":
+		// Like in Vim, the key that starts recording also stops it.
+		if len(registers.Recording()) > 0 {
+			return cmd.stop()
+		}
+		if len(cmd.register) == 0 {
+			registers.Await(cmd.record)
+			return nil
+		}
+		return cmd.record(cmd.register)
+
+	case "play":
+		if len(cmd.register) == 0 {
+			registers.Await(cmd.playAwaited)
+			return nil
+		}
+		return cmd.play(cmd.register)
+	}
+
+	return nil
+}
+
+func (cmd *Macro) record(register string) error {
+	err := cmd.api.Macros().Record(register)
+	if err != nil {
+		return err
+	}
+	log.Infof("Recording @%s", register)
+	return nil
+}
+
+func (cmd *Macro) stop() error {
+	registers := cmd.api.Macros()
+	register := registers.Stop()
+	if len(register) == 0 {
+		return fmt.Errorf("not recording")
+	}
+	log.Infof("Recorded %d commands into @%s", len(registers.Get(register)), register)
+	return registers.Save()
+}
+
+func (cmd *Macro) play(register string) error {
+	return cmd.api.Macros().Play(register, cmd.count, func(entry macro.Entry) error {
+		return cmd.api.ExecCount(entry.Command, entry.Count)
+	})
+}
+
+// playAwaited plays back a register chosen by key press. Since the register
+// was not known when the command ran, the resolved command is recorded here.
+func (cmd *Macro) playAwaited(register string) error {
+	err := cmd.play(register)
+	if err != nil {
+		return err
+	}
+	cmd.api.Macros().Add(macro.Entry{
+		Command: "macro play " + register,
+		Count:   cmd.count,
+	})
+	return nil
+}
@@ -0,0 +1,68 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/pkg/macro"
+	"github.com/stretchr/testify/assert"
+)
+
+var macroTests = []commands.Test{
+	// Valid forms
+	{`record`, true, setupTestMacro, nil, nil},
+	{`record a`, true, setupTestMacro, nil, []string{}},
+	{`record 1`, true, setupTestMacro, nil, []string{}},
+	{`stop`, true, setupTestMacro, nil, []string{}},
+	{`play`, true, setupTestMacro, nil, nil},
+	{`play q`, true, setupTestMacro, nil, []string{}},
+	{`play @`, true, setupTestMacro, nil, []string{}},
+	{`pl`, false, setupTestMacro, nil, []string{"play"}},
+
+	// Invalid forms
+	{``, false, setupTestMacro, nil, []string{"play", "record", "stop"}},
+	{`record ab`, false, setupTestMacro, nil, nil},
+	{`record @`, false, setupTestMacro, nil, nil},
+	{`stop a`, false, setupTestMacro, nil, nil},
+	{`foo`, false, setupTestMacro, nil, nil},
+
+	// Playback
+	{`play q`, true, setupTestMacro, testMacroPlay, nil},
+	{`play`, true, setupTestMacro, testMacroPlayAwaited, nil},
+}
+
+func setupTestMacro(data *commands.TestData) {
+	registers := macro.New("")
+	_ = registers.Record("q")
+	registers.Add(macro.Entry{Command: "cut", Count: 2})
+	registers.Stop()
+	data.MockAPI.On("Macros").Return(registers)
+}
+
+func testMacroPlay(data *commands.TestData) {
+	data.MockAPI.On("ExecCount", "cut", 2).Return(nil).Times(3)
+	data.Cmd.(commands.Counter).SetCount(3)
+	err := data.Cmd.Exec()
+	assert.NoError(data.T, err)
+	data.MockAPI.AssertExpectations(data.T)
+}
+
+func testMacroPlayAwaited(data *commands.TestData) {
+	registers := data.Api.Macros()
+	_ = registers.Record("r")
+	data.MockAPI.On("ExecCount", "cut", 2).Return(nil).Times(3)
+	data.Cmd.(commands.Counter).SetCount(3)
+
+	err := data.Cmd.Exec()
+	assert.NoError(data.T, err)
+	assert.True(data.T, registers.Awaiting())
+
+	err = registers.Key("q")
+	assert.NoError(data.T, err)
+	assert.Equal(data.T, []macro.Entry{{Command: "macro play q", Count: 3}}, registers.Get("r"))
+	data.MockAPI.AssertExpectations(data.T)
+}
+
+func TestMacro(t *testing.T) {
+	commands.TestVerb(t, "macro", macroTests)
+}
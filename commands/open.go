@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/spotify/link"
+)
+
+// Open loads the track, album, artist, playlist or show that a Spotify URL or URI points to.
+type Open struct {
+	command
+	api  api.API
+	link *spotify_link.Link
+}
+
+// NewOpen returns Open.
+func NewOpen(api api.API) Command {
+	return &Open{
+		api: api,
+	}
+}
+
+// Parse implements Command.
+func (cmd *Open) Parse() error {
+	var err error
+
+	// URLs may contain characters that are tokens of their own, such as `=`.
+	parts := make([]string, 0)
+	for {
+		tok, lit := cmd.Scan()
+		if tok == lexer.TokenEnd {
+			cmd.Unscan()
+			break
+		}
+		parts = append(parts, lit)
+	}
+
+	cmd.setTabCompleteEmpty()
+
+	text := strings.TrimSpace(strings.Join(parts, ""))
+	if len(text) == 0 {
+		return fmt.Errorf("usage: open <url>")
+	}
+
+	cmd.link, err = spotify_link.Parse(text)
+	if err != nil {
+		return err
+	}
+
+	return cmd.ParseEnd()
+}
+
+// Exec implements Command.
+func (cmd *Open) Exec() error {
+	return cmd.api.Exec("list goto " + string(cmd.link.URI()))
+}
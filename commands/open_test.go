@@ -0,0 +1,41 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var openTests = []commands.Test{
+	// Valid forms
+	{`spotify:album:1DFixLWuPkv3KT3TnV35m3`, true, nil, nil, []string{}},
+	{`https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC?si=a1b2c3d4`, true, nil, nil, []string{}},
+	{`"https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M"`, true, nil, nil, []string{}},
+
+	// Invalid forms
+	{``, false, nil, nil, nil},
+	{`foo`, false, nil, nil, nil},
+	{`spotify:genre:pop`, false, nil, nil, nil},
+	{`https://example.com/album/1DFixLWuPkv3KT3TnV35m3`, false, nil, nil, nil},
+
+	// Links are loaded by `list goto`
+	{`https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC?si=a1b2c3d4`, true, setupTestOpen, testOpen("spotify:track:4uLU6hMCjMI75M1A2tKUQC"), nil},
+	{`spotify:show:5CfCWKI5pZ28U0uOzXkDHe`, true, setupTestOpen, testOpen("spotify:show:5CfCWKI5pZ28U0uOzXkDHe"), nil},
+}
+
+func setupTestOpen(data *commands.TestData) {
+	data.MockAPI.On("Exec", mock.Anything).Return(nil)
+}
+
+func testOpen(uri string) func(data *commands.TestData) {
+	return func(data *commands.TestData) {
+		assert.NoError(data.T, data.Cmd.Exec())
+		data.MockAPI.AssertCalled(data.T, "Exec", "list goto "+uri)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	commands.TestVerb(t, "open", openTests)
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/list"
+)
+
+// Pane splits the screen into several panes, each showing its own list.
+type Pane struct {
+	command
+	api    api.API
+	action string
+	index  int
+}
+
+// NewPane returns Pane.
+func NewPane(api api.API) Command {
+	return &Pane{
+		api: api,
+	}
+}
+
+// Parse implements Command.
+func (cmd *Pane) Parse() error {
+	tok, lit := cmd.ScanIgnoreWhitespace()
+	cmd.setTabComplete(lit, []string{"close", "next", "only", "prev", "split", "vsplit"})
+
+	switch tok {
+	case lexer.TokenIdentifier:
+		break
+	default:
+		return fmt.Errorf("unexpected '%s', expected identifier", lit)
+	}
+
+	switch lit {
+	case "close", "next", "only", "prev", "split", "vsplit":
+		cmd.action = lit
+	default:
+		i, err := strconv.Atoi(lit)
+		if err != nil {
+			return fmt.Errorf("unexpected '%s', expected pane action or number", lit)
+		}
+		cmd.action = "goto"
+		cmd.index = i - 1
+	}
+
+	cmd.setTabCompleteEmpty()
+	return cmd.ParseEnd()
+}
+
+// Exec implements Command.
+func (cmd *Pane) Exec() error {
+	panes := cmd.api.UI().Panes()
+	n := len(panes.TableWidgets())
+
+	var err error
+
+	switch cmd.action {
+	case "split":
+		panes.Split(false)
+	case "vsplit":
+		panes.Split(true)
+	case "close":
+		err = panes.Close()
+	case "only":
+		panes.Only()
+	case "next":
+		err = panes.SetFocus((panes.Focus() + 1) % n)
+	case "prev":
+		err = panes.SetFocus((panes.Focus() + n - 1) % n)
+	case "goto":
+		err = panes.SetFocus(cmd.index)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The active list follows the focused pane.
+	cmd.api.SetList(cmd.api.UI().TableWidget().List())
+
+	return nil
+}
+
+// otherList returns the list shown in the pane after the focused pane.
+func otherList(a api.API) (list.List, error) {
+	panes := a.UI().Panes()
+	tables := panes.TableWidgets()
+	if len(tables) < 2 {
+		return nil, fmt.Errorf("no other pane; split the screen with `pane vsplit` first")
+	}
+	lst := tables[(panes.Focus()+1)%len(tables)].List()
+	if lst == nil {
+		return nil, fmt.Errorf("no list in the other pane")
+	}
+	return lst, nil
+}
+
+// paneSelection returns the selected tracks in a list, or the track under
+// its cursor if nothing is selected. The selection is cleared.
+func paneSelection(lst list.List) (list.List, error) {
+	selection := lst.Selection()
+	if selection.Len() == 0 {
+		row := lst.CursorRow()
+		if row == nil {
+			return nil, fmt.Errorf("no tracks selected in %s", lst.Name())
+		}
+		selection.Add(row)
+	}
+	lst.ClearSelection()
+
+	return selection, nil
+}
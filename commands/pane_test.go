@@ -0,0 +1,68 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var paneTests = []commands.Test{
+	// Valid forms
+	{`split`, true, nil, nil, []string{}},
+	{`vsplit`, true, nil, nil, []string{}},
+	{`close`, true, nil, nil, []string{}},
+	{`only`, true, nil, nil, []string{}},
+	{`next`, true, nil, nil, []string{}},
+	{`prev`, true, nil, nil, []string{}},
+	{`2`, true, nil, nil, []string{}},
+	{`s`, false, nil, nil, []string{"split"}},
+
+	// Invalid forms
+	{``, false, nil, nil, []string{"close", "next", "only", "prev", "split", "vsplit"}},
+	{`foo`, false, nil, nil, nil},
+	{`split now`, false, nil, nil, nil},
+
+	// Focus follows the active list
+	{`next`, true, setupTestPane, testPaneNext, nil},
+	{`vsplit`, true, setupTestPane, testPaneSplit, nil},
+}
+
+func setupTestPane(data *commands.TestData) {
+	tables := []api.TableWidget{&api.MockTableWidget{}, &api.MockTableWidget{}}
+	panes := &api.MockPanes{}
+	panes.On("TableWidgets").Return(tables)
+	panes.On("Focus").Return(1)
+
+	ui := &api.MockUI{}
+	ui.On("Panes").Return(panes)
+	ui.On("TableWidget").Return(tables[0])
+	tables[0].(*api.MockTableWidget).On("List").Return(list.New())
+
+	data.MockAPI.On("UI").Return(ui)
+	data.MockAPI.On("SetList", mock.Anything).Return()
+}
+
+func panes(data *commands.TestData) *api.MockPanes {
+	return data.Api.UI().Panes().(*api.MockPanes)
+}
+
+func testPaneNext(data *commands.TestData) {
+	panes(data).On("SetFocus", 0).Return(nil).Once()
+	assert.NoError(data.T, data.Cmd.Exec())
+	panes(data).AssertExpectations(data.T)
+	data.MockAPI.AssertCalled(data.T, "SetList", mock.Anything)
+}
+
+func testPaneSplit(data *commands.TestData) {
+	panes(data).On("Split", true).Return().Once()
+	assert.NoError(data.T, data.Cmd.Exec())
+	panes(data).AssertCalled(data.T, "Split", true)
+}
+
+func TestPane(t *testing.T) {
+	commands.TestVerb(t, "pane", paneTests)
+}
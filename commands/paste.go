@@ -15,52 +15,83 @@ type Paste struct {
 	api      api.API
 	position int
 	list     list.List
+	other    bool
+	toOther  bool
 }
 
 // NewPaste returns Paste.
 func NewPaste(api api.API) Command {
 	return &Paste{
-		api: api,
+		api:      api,
+		position: 1,
 	}
 }
 
 // Parse implements Command.
 func (cmd *Paste) Parse() error {
-	tok, lit := cmd.ScanIgnoreWhitespace()
-
 	cmd.list = cmd.api.List()
-	cmd.setTabCompleteVerbs(lit)
 
-	// Expect either "before" or "after".
-	switch tok {
-	case lexer.TokenIdentifier:
-		switch lit {
-		case "before":
+	// Expect "before" or "after", optionally followed by "other", or "to other".
+	// Fall back to "after" if no position is given.
+	for {
+		tok, lit := cmd.ScanIgnoreWhitespace()
+		cmd.setTabCompleteVerbs(lit)
+
+		switch tok {
+		case lexer.TokenIdentifier:
+			break
+		case lexer.TokenEnd:
+			return nil
+		default:
+			return fmt.Errorf("unexpected '%s', expected position", lit)
+		}
+
+		switch {
+		case lit == "other" && !cmd.other:
+			cmd.other = true
+			cmd.setTabCompleteEmpty()
+			return cmd.ParseEnd()
+		case lit == "to" && !cmd.other && cmd.position == 1:
+			return cmd.parseToOther()
+		case lit == "before" && !cmd.other:
 			cmd.position = 0
-		case "after":
+		case lit == "after" && !cmd.other:
 			cmd.position = 1
 		default:
 			return fmt.Errorf("unexpected '%s', expected position", lit)
 		}
-		cmd.setTabCompleteEmpty()
-		return cmd.ParseEnd()
-
-	// Fall back to "after" if no arguments given.
-	case lexer.TokenEnd:
-		cmd.position = 1
-
-	default:
-		return fmt.Errorf("unexpected '%s', expected position", lit)
 	}
+}
 
-	return nil
+// parseToOther parses the remainder of `paste to other`.
+func (cmd *Paste) parseToOther() error {
+	tok, lit := cmd.ScanIgnoreWhitespace()
+	cmd.setTabComplete(lit, []string{"other"})
+	if tok != lexer.TokenIdentifier || lit != "other" {
+		return fmt.Errorf("unexpected '%s', expected 'other'", lit)
+	}
+	cmd.toOther = true
+	cmd.setTabCompleteEmpty()
+	return cmd.ParseEnd()
 }
 
 // Exec implements Command.
 func (cmd *Paste) Exec() error {
+	if cmd.toOther {
+		return cmd.pasteToOther()
+	}
+
 	cursor := cmd.list.Cursor()
 	clipboard := cmd.api.Clipboards().Active()
 
+	if cmd.other {
+		var err error
+		clipboard, err = cmd.otherSelection()
+		if err != nil {
+			return err
+		}
+	}
+
 	if clipboard == nil {
 		return fmt.Errorf("no clipboard, try `cut` or `yank` first")
 	}
@@ -85,10 +116,45 @@ func (cmd *Paste) Exec() error {
 	return nil
 }
 
+// pasteToOther inserts the selected tracks after the cursor of the list in the other pane.
+func (cmd *Paste) pasteToOther() error {
+	other, err := otherList(cmd.api)
+	if err != nil {
+		return err
+	}
+
+	selection, err := paneSelection(cmd.list)
+	if err != nil {
+		return err
+	}
+
+	err = other.InsertList(selection, other.Cursor()+1)
+	if err != nil {
+		return err
+	}
+
+	cmd.api.Changed(api.ChangeList, other)
+	log.Infof("%d tracks inserted into %s", selection.Len(), other.Name())
+
+	return nil
+}
+
+// otherSelection returns the selected tracks in the other pane,
+// or the track under its cursor if nothing is selected.
+func (cmd *Paste) otherSelection() (list.List, error) {
+	other, err := otherList(cmd.api)
+	if err != nil {
+		return nil, err
+	}
+	return paneSelection(other)
+}
+
 // setTabCompleteVerbs sets the tab complete list to the list of available sub-commands.
 func (cmd *Paste) setTabCompleteVerbs(lit string) {
 	cmd.setTabComplete(lit, []string{
 		"after",
 		"before",
+		"other",
+		"to",
 	})
 }
@@ -0,0 +1,97 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/clipboard"
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var pasteTests = []commands.Test{
+	// Valid forms
+	{``, true, setupTestPaste, nil, []string{"after", "before", "other", "to"}},
+	{`aft`, false, setupTestPaste, nil, []string{"after"}},
+	{`before other`, true, setupTestPaste, nil, []string{}},
+	{`other`, true, setupTestPaste, nil, []string{}},
+	{`to other`, true, setupTestPaste, nil, []string{}},
+	{`to o`, false, setupTestPaste, nil, []string{"other"}},
+
+	// Invalid forms
+	{`foo`, false, setupTestPaste, nil, nil},
+	{`to`, false, setupTestPaste, nil, nil},
+	{`before to other`, false, setupTestPaste, nil, nil},
+	{`other to other`, false, setupTestPaste, nil, nil},
+
+	// Paste into the other pane
+	{`to other`, true, setupTestPaste, testPasteToOther, nil},
+	{`to other`, true, setupTestPasteEmptyPane, testPasteError("no list in the other pane"), nil},
+	{`other`, true, setupTestPasteEmptyPane, testPasteError("no list in the other pane"), nil},
+}
+
+func setupTestPaste(data *commands.TestData) {
+	focused := list.New()
+	focused.Add(list.NewRow("1", list.DataTypeTrack, nil))
+	focused.Add(list.NewRow("2", list.DataTypeTrack, nil))
+	focused.SetSelected(1, true)
+
+	other := list.New()
+	other.Add(list.NewRow("3", list.DataTypeTrack, nil))
+	other.Add(list.NewRow("4", list.DataTypeTrack, nil))
+
+	tables := []api.TableWidget{&api.MockTableWidget{}, &api.MockTableWidget{}}
+	tables[1].(*api.MockTableWidget).On("List").Return(other)
+	panes := &api.MockPanes{}
+	panes.On("TableWidgets").Return(tables)
+	panes.On("Focus").Return(0)
+
+	ui := &api.MockUI{}
+	ui.On("Panes").Return(panes)
+
+	data.MockAPI.On("List").Return(focused)
+	data.MockAPI.On("UI").Return(ui)
+	data.MockAPI.On("Changed", api.ChangeList, mock.Anything).Return()
+}
+
+// setupTestPasteEmptyPane sets up a split screen where the other pane shows no list.
+func setupTestPasteEmptyPane(data *commands.TestData) {
+	tables := []api.TableWidget{&api.MockTableWidget{}, &api.MockTableWidget{}}
+	tables[1].(*api.MockTableWidget).On("List").Return(nil)
+	panes := &api.MockPanes{}
+	panes.On("TableWidgets").Return(tables)
+	panes.On("Focus").Return(0)
+
+	ui := &api.MockUI{}
+	ui.On("Panes").Return(panes)
+
+	data.MockAPI.On("Clipboards").Return(clipboard.New())
+	data.MockAPI.On("List").Return(list.New())
+	data.MockAPI.On("UI").Return(ui)
+}
+
+func testPasteError(expected string) func(data *commands.TestData) {
+	return func(data *commands.TestData) {
+		assert.EqualError(data.T, data.Cmd.Exec(), expected)
+	}
+}
+
+func testPasteToOther(data *commands.TestData) {
+	assert.NoError(data.T, data.Cmd.Exec())
+
+	other := data.Api.UI().Panes().TableWidgets()[1].List()
+	ids := make([]string, 0, other.Len())
+	for i := 0; i < other.Len(); i++ {
+		ids = append(ids, other.Row(i).ID())
+	}
+	assert.Equal(data.T, []string{"3", "2", "4"}, ids)
+	assert.Equal(data.T, 2, data.Api.List().Len())
+	assert.False(data.T, data.Api.List().Selected(1))
+	data.MockAPI.AssertCalled(data.T, "Changed", api.ChangeList, other)
+}
+
+func TestPaste(t *testing.T) {
+	commands.TestVerb(t, "paste", pasteTests)
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/ambientsound/visp/input/lexer"
 )
 
+// playableTypes are the kinds of rows that can be played, by their Spotify URI.
+var playableTypes = []list.DataType{list.DataTypeTrack, list.DataTypeEpisode}
+
 // Play plays songs in the MPD playlist.
 type Play struct {
 	command
@@ -128,8 +131,8 @@ func (cmd *Play) deviceID() (*spotify.ID, error) {
 func (cmd *Play) playCursor() error {
 	row := cmd.tracklist.CursorRow()
 
-	if row.Kind() != list.DataTypeTrack {
-		return fmt.Errorf("cannot play: %w", ErrMsgDataType(row.Kind(), list.DataTypeTrack))
+	if err := ErrMsgDataType(row.Kind(), playableTypes...); err != nil {
+		return fmt.Errorf("cannot play: %w", err)
 	}
 
 	// Get a device ID for playback.
@@ -174,7 +177,7 @@ func (cmd *Play) playCursor() error {
 	defer cmd.api.Changed(api.ChangeDevice, nil)
 
 	// Start playing with correct parameters.
-	trackuri := spotify.URI("spotify:track:" + row.ID())
+	trackuri := row.URI()
 	return cmd.client.PlayOpt(context.TODO(), &spotify.PlayOptions{
 		DeviceID:        deviceID,
 		URIs:            uris,
@@ -203,13 +206,16 @@ func (cmd *Play) playSelection() error {
 		return cmd.playCursor()
 	}
 
-	cmd.tracklist.ClearSelection()
-
 	uris := make([]spotify.URI, selection.Len())
 	for i, track := range selection.All() {
+		if err := ErrMsgDataType(track.Kind(), playableTypes...); err != nil {
+			return fmt.Errorf("cannot play: %w", err)
+		}
 		uris[i] = track.URI()
 	}
 
+	cmd.tracklist.ClearSelection()
+
 	// TODO: queue is unsupported by the Spotify Web API
 	// https://github.com/spotify/web-api/issues/462
 
@@ -1,9 +1,18 @@
 package commands_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/player"
+	"github.com/ambientsound/visp/spotify/tracklist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
 )
 
 var playTests = []commands.Test{
@@ -21,3 +30,53 @@ var playTests = []commands.Test{
 func TestPlay(t *testing.T) {
 	commands.TestVerb(t, "play", playTests)
 }
+
+// Episodes of a show opened from a link are played by their URIs.
+func TestPlayEpisode(t *testing.T) {
+	var body struct {
+		URIs   []string `json:"uris"`
+		Offset struct {
+			URI string `json:"uri"`
+		} `json:"offset"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/me/player/play", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := spotify.New(server.Client(), spotify.WithBaseURL(server.URL+"/"))
+
+	setup := func(data *commands.TestData) {
+		page := &spotify.SimpleEpisodePage{
+			Episodes: []spotify.EpisodePage{
+				{ID: "ep1", Name: "Pilot", URI: "spotify:episode:ep1"},
+				{ID: "ep2", Name: "Sequel", URI: "spotify:episode:ep2"},
+			},
+		}
+		lst, err := spotify_tracklist.NewFromSimpleEpisodePage(*client, page, spotify.SimpleShow{Name: "Show"})
+		require.NoError(data.T, err)
+		lst.SetCursor(1)
+
+		state := player.NewState(spotify.PlayerState{})
+		state.Device.ID = "speaker"
+
+		data.MockAPI.On("Spotify").Return(client, nil)
+		data.MockAPI.On("List").Return(lst)
+		data.MockAPI.On("PlayerStatus").Return(*state)
+		data.MockAPI.On("Changed", mock.Anything, mock.Anything).Return()
+	}
+
+	play := func(data *commands.TestData) {
+		assert.Equal(data.T, []string{commands.TracklistContext, commands.GlobalContext}, commands.Contexts(data.Api))
+		require.NoError(data.T, data.Cmd.Exec())
+		assert.Equal(data.T, []string{"spotify:episode:ep1", "spotify:episode:ep2"}, body.URIs)
+		assert.Equal(data.T, "spotify:episode:ep2", body.Offset.URI)
+	}
+
+	commands.TestVerb(t, "play", []commands.Test{
+		{`cursor`, true, setup, play, nil},
+	})
+}
@@ -295,13 +295,13 @@ func (cmd *Recommend) Exec() error {
 	}
 
 	limit := options.GetInt(options.Limit)
-	recommendations, err := client.GetRecommendations(context.TODO(), *seeds, cmd.attributes, spotify.Limit(limit))
+	recommendations, err := client.GetRecommendations(context.TODO(), *seeds, cmd.attributes, spotify.Market(spotify.MarketFromToken), spotify.Limit(limit))
 
 	if err != nil {
 		return err
 	}
 
-	fullTracks, err := spotify_tracklist.SimpleTracksToFullTracks(client, recommendations.Tracks)
+	fullTracks, err := spotify_tracklist.SimpleTracksToFullTracks(client, recommendations.Tracks, spotify.Market(spotify.MarketFromToken))
 	if err != nil {
 		return err
 	}
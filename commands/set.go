@@ -9,6 +9,8 @@ import (
 	"github.com/ambientsound/visp/input/parser"
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/albumart"
+	"github.com/ambientsound/visp/pkg/format"
 )
 
 // Set manipulates a Options table by parsing input tokens from the "set" command.
@@ -88,6 +90,11 @@ func (cmd *Set) Exec() error {
 	for _, tok := range cmd.tokens {
 		opt := options.Get(tok.Key)
 
+		// Column options are created the first time they are set.
+		if opt == nil && options.IsColumnOption(tok.Key) {
+			opt = ""
+		}
+
 		if opt == nil {
 			return fmt.Errorf("no such option: %s", tok.Key)
 		}
@@ -131,6 +138,10 @@ func (cmd *Set) Exec() error {
 				prnt()
 				continue
 			}
+			err := validateOption(tok.Key, tok.Value)
+			if err != nil {
+				return err
+			}
 			options.Set(tok.Key, tok.Value)
 		}
 
@@ -171,3 +182,32 @@ func (cmd *Set) setTabCompleteOption(tok parser.OptionToken) {
 		"=",
 	})
 }
+
+// validateOption returns an error if value is not valid for a string option.
+func validateOption(key, value string) error {
+	switch {
+	case options.IsColumnOption(key):
+		return validateColumnOption(key, value)
+	case key == options.AlbumArtProtocol:
+		return albumart.ValidProtocol(value)
+	}
+	return nil
+}
+
+// validateColumnOption returns an error if value is not valid for a column option.
+func validateColumnOption(key, value string) error {
+	_, setting := options.ColumnSetting(key)
+	switch setting {
+	case options.ColumnAlign:
+		return format.ValidAlign(value)
+	case options.ColumnFormat:
+		_, err := format.Get(value)
+		return err
+	case options.ColumnTruncate:
+		return format.ValidTruncate(value)
+	case options.ColumnWidth:
+		_, err := format.Width(value, 0)
+		return err
+	}
+	return nil
+}
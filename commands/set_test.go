@@ -22,8 +22,19 @@ var setTests = []commands.Test{
 	{`baz=`, true, testSetInit, testFooSet(`baz`, ``, true), []string{`="foobar"`, `=`}},
 	{`bool`, true, testSetInit, nil, []string{`bool`}},
 
+	// Column options don't need to exist, but are validated
+	{`column.time.align=right`, true, testSetInit, testFooSet(`column.time.align`, `right`, true), []string{}},
+	{`column.title.width=40%`, true, testSetInit, testFooSet(`column.title.width`, `40%`, true), []string{}},
+	{`column.time.align=sideways`, true, testSetInit, testFooSet(`column.time.align`, ``, false), []string{}},
+	{`column.date.format=nonexist`, true, testSetInit, testFooSet(`column.date.format`, ``, false), []string{}},
+
+	// Album art protocols are validated
+	{`albumart.protocol=sixel`, true, testSetInit, testFooSet(`albumart.protocol`, `sixel`, true), []string{}},
+	{`albumart.protocol=ascii`, true, testSetInit, testFooSet(`albumart.protocol`, ``, false), []string{}},
+
 	// Invalid forms
 	{`nonexist=foo`, true, testSetInit, testFooSet(`nonexist`, ``, false), []string{}},
+	{`column.time.color=red`, true, testSetInit, testFooSet(`column.time.color`, ``, false), []string{}},
 	{`$=""`, false, testSetInit, nil, []string{}},
 }
 
@@ -38,6 +49,7 @@ func testSetInit(test *commands.TestData) {
 	options.Set("baz", "foobar")
 	options.Set("int", 0)
 	options.Set("bool", false)
+	options.Set(options.AlbumArtProtocol, "auto")
 }
 
 func testFooSet(key, check string, ok bool) func(*commands.TestData) {
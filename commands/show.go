@@ -18,6 +18,7 @@ type Show struct {
 	api  api.API
 	list list.List
 	text string
+	verb string
 }
 
 // NewShow returns Show.
@@ -48,7 +49,11 @@ func (cmd *Show) Parse() error {
 		case *spotify_library.List:
 			cmd.text = lst.CursorRow().ID()
 		default:
-			return fmt.Errorf("`show selected` may only be used inside the windows, library, and clipboard views")
+			row := lst.CursorRow()
+			if row == nil || row.Kind() != list.DataTypeHelp || row.Fields()["section"] != HelpSectionCommand {
+				return fmt.Errorf("`show selected` may only be used inside the windows, library, clipboard, and help views")
+			}
+			cmd.verb = row.Fields()["name"]
 		}
 	case "windows":
 		cmd.list = cmd.api.Db()
@@ -62,6 +67,8 @@ func (cmd *Show) Parse() error {
 		cmd.list = cmd.api.Clipboards()
 	case "history":
 		cmd.list = cmd.api.History()
+	case "nowplaying":
+		cmd.list = cmd.api.NowPlaying()
 	default:
 		return fmt.Errorf("can't show '%s'; no such window", lit)
 	}
@@ -73,6 +80,9 @@ func (cmd *Show) Parse() error {
 
 // Exec implements Command.
 func (cmd *Show) Exec() error {
+	if len(cmd.verb) > 0 {
+		return cmd.api.Exec("help " + cmd.verb)
+	}
 	if cmd.list == nil {
 		return cmd.api.Exec("list goto " + cmd.text)
 	}
@@ -88,6 +98,7 @@ func (cmd *Show) setTabCompleteVerbs(lit string) {
 		"keybindings",
 		"library",
 		"logs",
+		"nowplaying",
 		"selected",
 		"windows",
 	})
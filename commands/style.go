@@ -91,6 +91,7 @@ func (cmd *Style) setTabCompleteStyles(lit string) {
 		"bold",
 		"dim",
 		"reverse",
+		"strikethrough",
 		"underline",
 	}
 	cmd.setTabComplete(lit, list)
@@ -106,6 +107,8 @@ func (cmd *Style) mergeStyle(lit string) error {
 		cmd.styleValue = cmd.styleValue.Dim(true)
 	case "reverse":
 		cmd.styleValue = cmd.styleValue.Reverse(true)
+	case "strikethrough":
+		cmd.styleValue = cmd.styleValue.StrikeThrough(true)
 	case "underline":
 		cmd.styleValue = cmd.styleValue.Underline(true)
 	default:
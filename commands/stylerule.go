@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/pkg/stylerule"
+)
+
+// StyleRule styles table rows depending on the values of their fields.
+type StyleRule struct {
+	command
+	api   api.API
+	rule  stylerule.Rule
+	clear bool
+	show  bool
+}
+
+// NewStyleRule returns StyleRule.
+func NewStyleRule(api api.API) Command {
+	return &StyleRule{
+		api: api,
+	}
+}
+
+// Parse implements Command.
+func (cmd *StyleRule) Parse() error {
+	tok, lit := cmd.ScanIgnoreWhitespace()
+	cmd.setTabCompleteStyles(lit)
+
+	switch tok {
+	case lexer.TokenEnd:
+		cmd.show = true
+		return nil
+	case lexer.TokenIdentifier:
+		break
+	default:
+		return fmt.Errorf("unexpected '%s', expected style name", lit)
+	}
+
+	if lit == "clear" {
+		cmd.clear = true
+		cmd.setTabCompleteEmpty()
+		return cmd.ParseEnd()
+	}
+
+	styleName := lit
+
+	tok, lit = cmd.Scan()
+	switch tok {
+	case lexer.TokenEnd:
+		return fmt.Errorf("unexpected END, expected predicate")
+	case lexer.TokenWhitespace:
+		break
+	default:
+		return fmt.Errorf("unexpected '%s', expected whitespace", lit)
+	}
+
+	// The predicate contains operators that the lexer splits on,
+	// so everything up to the next whitespace is part of it.
+	predicate := ""
+	tok, lit = cmd.Scan()
+	for tok != lexer.TokenEnd && tok != lexer.TokenWhitespace && tok != lexer.TokenComment {
+		predicate += lit
+		tok, lit = cmd.Scan()
+	}
+	cmd.setTabCompleteFields(predicate)
+
+	if len(predicate) == 0 {
+		return fmt.Errorf("unexpected END, expected predicate")
+	}
+
+	var err error
+	cmd.rule, err = stylerule.Parse(styleName, predicate)
+	if err != nil {
+		return err
+	}
+
+	if tok == lexer.TokenWhitespace {
+		cmd.setTabCompleteEmpty()
+		return cmd.ParseEnd()
+	}
+
+	return nil
+}
+
+// Exec implements Command.
+func (cmd *StyleRule) Exec() error {
+	rules := cmd.api.StyleRules()
+
+	switch {
+	case cmd.show:
+		if len(rules.All()) == 0 {
+			log.Infof("No style rules defined.")
+		}
+		for _, rule := range rules.All() {
+			log.Infof("stylerule %s", rule)
+		}
+	case cmd.clear:
+		rules.Clear()
+	default:
+		rules.Add(cmd.rule)
+	}
+
+	return nil
+}
+
+// setTabCompleteStyles sets the tab complete list to the available style names.
+func (cmd *StyleRule) setTabCompleteStyles(lit string) {
+	names := []string{"clear"}
+	for key := range cmd.api.Styles() {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	cmd.setTabComplete(lit, names)
+}
+
+// setTabCompleteFields sets the tab complete list to the fields of the current list.
+func (cmd *StyleRule) setTabCompleteFields(lit string) {
+	names := cmd.api.List().ColumnNames()
+	sort.Strings(names)
+	cmd.setTabComplete(lit, names)
+}
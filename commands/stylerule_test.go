@@ -0,0 +1,66 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/pkg/stylerule"
+	"github.com/ambientsound/visp/style"
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var styleRuleTests = []commands.Test{
+	// Valid forms
+	{``, true, setupTestStyleRule, nil, []string{"clear", "dim", "red"}},
+	{`clear`, true, setupTestStyleRule, testStyleRuleClear, []string{}},
+	{`red explicit`, true, setupTestStyleRule, testStyleRule("red explicit"), []string{"explicit"}},
+	{`dim popularity<0.20`, true, setupTestStyleRule, testStyleRule("dim popularity<0.20"), []string{}},
+	{`dim popularity>=0.20 `, true, setupTestStyleRule, testStyleRule("dim popularity>=0.20"), []string{}},
+	{`red !playable`, true, setupTestStyleRule, testStyleRule("red !playable"), []string{}},
+	{`red artist="The Beatles"`, true, setupTestStyleRule, testStyleRule(`red artist="The Beatles"`), []string{}},
+	{`red "artist~the beatles"`, true, setupTestStyleRule, testStyleRule(`red artist~"the beatles"`), []string{}},
+	{`r`, false, setupTestStyleRule, nil, []string{"red"}},
+
+	// Invalid forms
+	{`red`, false, setupTestStyleRule, nil, nil},
+	{`red =foo`, false, setupTestStyleRule, nil, nil},
+	{`red explicit foo`, false, setupTestStyleRule, nil, nil},
+	{`clear foo`, false, setupTestStyleRule, nil, nil},
+}
+
+func TestStyleRule(t *testing.T) {
+	commands.TestVerb(t, "stylerule", styleRuleTests)
+}
+
+func setupTestStyleRule(data *commands.TestData) {
+	lst := list.New()
+	lst.Add(list.NewRow("1", list.DataTypeTrack, map[string]string{"explicit": "yes", "popularity": "0.10"}))
+
+	rules := stylerule.New()
+	rule, _ := stylerule.Parse("red", "foo")
+	rules.Add(rule)
+
+	data.MockAPI.On("List").Return(lst)
+	data.MockAPI.On("StyleRules").Return(rules)
+	data.MockAPI.On("Styles").Return(style.Stylesheet{
+		"dim": tcell.StyleDefault.Dim(true),
+		"red": tcell.StyleDefault.Foreground(tcell.ColorRed),
+	})
+}
+
+func testStyleRule(expected string) func(*commands.TestData) {
+	return func(data *commands.TestData) {
+		assert.NoError(data.T, data.Cmd.Exec())
+		rules := data.Api.StyleRules().All()
+		if assert.Len(data.T, rules, 2) {
+			assert.Equal(data.T, expected, rules[1].String())
+		}
+	}
+}
+
+func testStyleRuleClear(data *commands.TestData) {
+	assert.NoError(data.T, data.Cmd.Exec())
+	assert.Len(data.T, data.Api.StyleRules().All(), 0)
+}
@@ -11,8 +11,10 @@ import (
 type Viewport struct {
 	command
 	api        api.API
+	count      int
 	movecursor bool
 	relative   int
+	scroll     bool
 }
 
 // NewViewport returns Viewport.
@@ -33,6 +35,8 @@ func (cmd *Viewport) Parse() error {
 		return fmt.Errorf("unexpected '%s', expected identifier", lit)
 	}
 
+	cmd.scroll = true
+
 	switch lit {
 	case "down":
 		cmd.relative = 1
@@ -112,12 +116,22 @@ func (cmd *Viewport) scrollToCursorAnchor(position int) {
 		cmd.relative = cursor - y/2 - ymin
 	}
 	cmd.movecursor = false
+	cmd.scroll = false
+}
+
+// SetCount implements Counter. Scrolling is repeated count times.
+func (cmd *Viewport) SetCount(count int) {
+	cmd.count = count
 }
 
 // Exec implements Command.
 func (cmd *Viewport) Exec() error {
 	widget := cmd.api.UI().TableWidget()
 
+	if cmd.scroll && cmd.count > 1 {
+		cmd.relative *= cmd.count
+	}
+
 	widget.ScrollViewport(cmd.relative, cmd.movecursor)
 
 	return nil
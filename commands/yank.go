@@ -2,20 +2,33 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ambientsound/visp/api"
 	"github.com/ambientsound/visp/input/lexer"
 	"github.com/ambientsound/visp/list"
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/osc52"
+	"github.com/ambientsound/visp/spotify/link"
+)
+
+// Formats of text copied to the system clipboard.
+const (
+	yankURL  = "url"
+	yankURI  = "uri"
+	yankText = "text"
 )
 
 // Yank copies tracks from the songlist into the clipboard.
 type Yank struct {
 	command
 	api     api.API
+	count   int
 	current bool
+	format  string
 	list    list.List
+	other   bool
 }
 
 // NewYank returns Yank.
@@ -33,9 +46,21 @@ func (cmd *Yank) Parse() error {
 
 	switch tok {
 	case lexer.TokenIdentifier:
-		if lit == "current" {
+		switch lit {
+		case yankURL, yankURI, yankText:
+			cmd.format = lit
+			tok, lit = cmd.ScanIgnoreWhitespace()
+			cmd.setTabComplete(lit, []string{"current"})
+			if tok == lexer.TokenIdentifier && lit == "current" {
+				cmd.current = true
+			} else {
+				cmd.Unscan()
+			}
+		case "current":
 			cmd.current = true
-		} else {
+		case "other":
+			cmd.other = true
+		default:
 			cmd.Unscan()
 		}
 	}
@@ -43,9 +68,25 @@ func (cmd *Yank) Parse() error {
 	return cmd.ParseEnd()
 }
 
+// SetCount implements Counter. If nothing is selected, count rows are copied, starting at the cursor.
+func (cmd *Yank) SetCount(count int) {
+	cmd.count = count
+}
+
 // Exec implements Command.
 func (cmd *Yank) Exec() error {
 	switch {
+	case cmd.other:
+		other, err := otherList(cmd.api)
+		if err != nil {
+			return err
+		}
+		cmd.list, err = paneSelection(other)
+		if err != nil {
+			return err
+		}
+		cmd.list.SetVisibleColumns(other.ColumnNames())
+
 	case cmd.current == true:
 		row := cmd.api.PlayerStatus().TrackRow
 		if len(row.ID()) == 0 {
@@ -57,6 +98,7 @@ func (cmd *Yank) Exec() error {
 
 	default:
 		tracklist := cmd.api.List()
+		selectCount(tracklist, cmd.count)
 		cmd.list = tracklist.Selection()
 
 		if cmd.list.Len() == 0 {
@@ -67,14 +109,67 @@ func (cmd *Yank) Exec() error {
 		tracklist.ClearSelection()
 	}
 
+	if len(cmd.format) > 0 {
+		return cmd.copyToSystem()
+	}
+
 	cmd.api.Clipboards().Insert(cmd.list)
+
 	log.Infof("%d songs stored in %s", cmd.list.Len(), cmd.list.Name())
 
 	return nil
 }
 
+// copyToSystem writes the yanked tracks to the terminal's system clipboard, one line per track.
+func (cmd *Yank) copyToSystem() error {
+	lines := make([]string, 0, cmd.list.Len())
+	for i := 0; i < cmd.list.Len(); i++ {
+		line := yankLine(cmd.list.Row(i), cmd.format)
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return fmt.Errorf("nothing to copy in %s format", cmd.format)
+	}
+
+	err := osc52.Copy(strings.Join(lines, "\n"))
+	if err != nil {
+		return fmt.Errorf("copy to system clipboard: %s", err)
+	}
+
+	log.Infof("%d lines copied to the system clipboard", len(lines))
+
+	return nil
+}
+
+// yankLine formats a row as a Spotify URL, a Spotify URI, or an "artist – title" line.
+func yankLine(row list.Row, format string) string {
+	switch format {
+	case yankURL:
+		return spotify_link.URL(row.URI())
+	case yankURI:
+		return string(row.URI())
+	}
+
+	title := row.Get("title")
+	if len(title) == 0 {
+		title = row.Get("name")
+	}
+	artist := row.Get("artist")
+	if len(artist) == 0 {
+		return title
+	}
+	return artist + " – " + title
+}
+
 func (cmd *Yank) setTabCompleteVerbs(lit string) {
 	cmd.setTabComplete(lit, []string{
 		"current",
+		"other",
+		yankText,
+		yankURI,
+		yankURL,
 	})
 }
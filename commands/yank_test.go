@@ -0,0 +1,81 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/ambientsound/visp/clipboard"
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/pkg/osc52"
+	"github.com/stretchr/testify/assert"
+)
+
+var yankTests = []commands.Test{
+	// Valid forms
+	{``, true, nil, nil, []string{"current", "other", "text", "uri", "url"}},
+	{`current`, true, nil, nil, []string{"current"}},
+	{`other`, true, nil, nil, []string{"other"}},
+	{`url`, true, nil, nil, nil},
+	{`uri current`, true, nil, nil, []string{"current"}},
+	{`text `, true, nil, nil, []string{"current"}},
+
+	// Invalid forms
+	{`foo`, false, nil, nil, nil},
+	{`u`, false, nil, nil, []string{"uri", "url"}},
+	{`url other`, false, nil, nil, nil},
+	{`current url`, false, nil, nil, nil},
+
+	// Copy to the system clipboard
+	{`url`, true, setupTestYank, testYankFormat("https://open.spotify.com/track/1\nhttps://open.spotify.com/album/2"), nil},
+	{`uri`, true, setupTestYank, testYankFormat("spotify:track:1\nspotify:album:2"), nil},
+	{`text`, true, setupTestYank, testYankFormat("Artist – Song\nArtist – Album\nLog message"), nil},
+
+	// Copy from the other pane
+	{`other`, true, setupTestPaste, testYankOther, nil},
+}
+
+func setupTestYank(data *commands.TestData) {
+	lst := list.New()
+	lst.Add(list.NewRow("1", list.DataTypeTrack, map[string]string{"artist": "Artist", "title": "Song"}))
+	lst.Add(list.NewRow("2", list.DataTypeAlbum, map[string]string{"artist": "Artist", "title": "Album"}))
+	lst.Add(list.NewRow("3", list.DataTypeLogLine, map[string]string{"name": "Log message"}))
+	for i := 0; i < lst.Len(); i++ {
+		lst.SetSelected(i, true)
+	}
+	data.MockAPI.On("List").Return(lst)
+}
+
+func testYankFormat(expected string) func(data *commands.TestData) {
+	return func(data *commands.TestData) {
+		buf := &bytes.Buffer{}
+		osc52.Output = buf
+		defer func() {
+			osc52.Output = os.Stdout
+		}()
+
+		assert.NoError(data.T, data.Cmd.Exec())
+		assert.Equal(data.T, osc52.Wrap(osc52.Sequence(expected)), buf.String())
+		assert.False(data.T, data.Api.List().Selected(0))
+	}
+}
+
+func testYankOther(data *commands.TestData) {
+	clipboards := clipboard.New()
+	data.MockAPI.On("Clipboards").Return(clipboards)
+
+	other := data.Api.UI().Panes().TableWidgets()[1].List()
+	other.SetCursor(1)
+	assert.NoError(data.T, data.Cmd.Exec())
+
+	yanked := clipboards.Active()
+	if assert.NotNil(data.T, yanked) && assert.Equal(data.T, 1, yanked.Len()) {
+		assert.Equal(data.T, "4", yanked.Row(0).ID())
+	}
+	assert.Equal(data.T, 2, other.Len())
+}
+
+func TestYank(t *testing.T) {
+	commands.TestVerb(t, "yank", yankTests)
+}
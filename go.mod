@@ -4,6 +4,7 @@ require (
 	github.com/blevesearch/bleve/v2 v2.3.1
 	github.com/gdamore/tcell/v2 v2.4.0
 	github.com/go-chi/chi v1.5.4
+	github.com/godbus/dbus/v5 v5.1.0
 	github.com/google/uuid v1.3.0
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/magiconair/properties v1.8.6 // indirect
@@ -15,10 +16,11 @@ require (
 	github.com/stretchr/objx v0.3.0 // indirect
 	github.com/stretchr/testify v1.7.0
 	github.com/zmb3/spotify v1.3.0
-	github.com/zmb3/spotify/v2 v2.0.1 // indirect
-	golang.org/x/net v0.0.0-20220225172249-27dd8689420f // indirect
+	github.com/zmb3/spotify/v2 v2.0.1
+	go.starlark.net v0.0.0-20220328144851-d1966c6b9fcd
+	golang.org/x/net v0.0.0-20220225172249-27dd8689420f
 	golang.org/x/oauth2 v0.0.0-20220223155221-ee480838109b
-	golang.org/x/sys v0.0.0-20220307203707-22a9840ba4d7 // indirect
+	golang.org/x/sys v0.0.0-20220307203707-22a9840ba4d7
 	gopkg.in/ini.v1 v1.66.4 // indirect
 )
 
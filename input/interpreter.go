@@ -11,7 +11,14 @@ import (
 
 // Interpreter reads user input, tokenizes it, and dispatches the tokens to their respective commands.
 type Interpreter struct {
-	api api.API
+	api  api.API
+	last repeatable
+}
+
+// repeatable is the last command that changed a list.
+type repeatable struct {
+	line  string
+	count int
 }
 
 func NewCLI(api api.API) *Interpreter {
@@ -23,6 +30,56 @@ func NewCLI(api api.API) *Interpreter {
 // Exec scans an input line, finds the verb in the command directory,
 // and hands execution over to the command.
 func (i *Interpreter) Exec(line string) error {
+	return i.ExecCount(line, 0)
+}
+
+// ExecCount executes an input line with a count prefix. Commands that
+// implement commands.Counter receive the count, and other commands are
+// executed count times. A count of zero means that no count was given.
+func (i *Interpreter) ExecCount(line string, count int) error {
+	verb, cmd, err := i.parse(line)
+	if cmd == nil || err != nil {
+		return err
+	}
+
+	if counter, ok := cmd.(commands.Counter); ok {
+		counter.SetCount(count)
+		err = cmd.Exec()
+	} else {
+		err = cmd.Exec()
+		for n := 1; err == nil && n < count; n++ {
+			_, cmd, err = i.parse(line)
+			if err == nil {
+				err = cmd.Exec()
+			}
+		}
+	}
+
+	if err == nil && commands.Describe(verb).Changes {
+		i.last = repeatable{
+			line:  line,
+			count: count,
+		}
+	}
+
+	return err
+}
+
+// Repeat executes the last command that changed a list again.
+// If count is zero, the count of the original command is used.
+func (i *Interpreter) Repeat(count int) error {
+	if len(i.last.line) == 0 {
+		return fmt.Errorf("no command to repeat")
+	}
+	if count == 0 {
+		count = i.last.count
+	}
+	return i.ExecCount(i.last.line, count)
+}
+
+// parse scans an input line and returns its verb and the parsed command.
+// The command is nil if the line is empty or a comment.
+func (i *Interpreter) parse(line string) (string, commands.Command, error) {
 
 	// Create the token scanner.
 	reader := strings.NewReader(line)
@@ -33,26 +90,25 @@ func (i *Interpreter) Exec(line string) error {
 	tok, verb := scanner.ScanIgnoreWhitespace()
 	switch tok {
 	case lexer.TokenEnd, lexer.TokenComment:
-		return nil
+		return "", nil, nil
 	case lexer.TokenIdentifier:
 		break
 	default:
-		return fmt.Errorf("unexpected '%s', expected verb", verb)
+		return "", nil, fmt.Errorf("unexpected '%s', expected verb", verb)
 	}
 
 	// Instantiate the command.
 	cmd := commands.New(verb, i.api)
 	if cmd == nil {
-		return fmt.Errorf("not a command: %s", verb)
+		return "", nil, fmt.Errorf("not a command: %s", verb)
 	}
 
 	// Parse the command into an AST.
 	cmd.SetScanner(scanner)
 	err := cmd.Parse()
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
-	// Execute the AST.
-	return cmd.Exec()
+	return verb, cmd, nil
 }
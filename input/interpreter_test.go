@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/commands"
 	"github.com/ambientsound/visp/input"
 	"github.com/ambientsound/visp/options"
 	"github.com/spf13/viper"
@@ -30,3 +31,39 @@ func TestCLISet(t *testing.T) {
 
 	assert.Equal(t, "something", options.GetString("foo"))
 }
+
+// counting is a command that records how many times it was executed.
+type counting struct {
+	commands.Command
+	runs *int
+}
+
+func (cmd *counting) Exec() error {
+	*cmd.runs++
+	return nil
+}
+
+// TestCLIRepeat tests that commands are executed once per count, and that
+// the last command changing a list can be repeated.
+func TestCLIRepeat(t *testing.T) {
+	runs := 0
+	ctor := func(a api.API) commands.Command {
+		return &counting{Command: commands.NewRedraw(a), runs: &runs}
+	}
+	err := commands.Register("testchange", ctor, commands.Description{Changes: true})
+	assert.NoError(t, err)
+	defer commands.Unregister("testchange")
+
+	iface := input.NewCLI(&api.MockAPI{})
+
+	assert.Error(t, iface.Repeat(0))
+
+	assert.NoError(t, iface.ExecCount("testchange", 3))
+	assert.Equal(t, 3, runs)
+
+	assert.NoError(t, iface.Repeat(0))
+	assert.Equal(t, 6, runs)
+
+	assert.NoError(t, iface.Repeat(1))
+	assert.Equal(t, 7, runs)
+}
@@ -3,6 +3,8 @@ package keys
 import (
 	"fmt"
 	"github.com/ambientsound/visp/log"
+	"sort"
+	"strconv"
 
 	"github.com/ambientsound/visp/keysequence"
 	"github.com/gdamore/tcell/v2"
@@ -13,11 +15,16 @@ type Binding struct {
 	Command  string
 	Context  string
 	Sequence keysequence.KeySequence
+
+	// Count is the number typed before the key sequence, such as 5 in `5j`.
+	// It is only set on bindings returned from Match, and is zero if no number was typed.
+	Count int
 }
 
 // Sequencer holds all the keyboard bindings and their action mappings.
 type Sequencer struct {
 	binds []Binding
+	count int
 	event *tcell.EventKey
 	input keysequence.KeySequence
 }
@@ -62,18 +69,53 @@ func (s *Sequencer) RemoveBind(context string, seq keysequence.KeySequence) erro
 }
 
 // KeyInput feeds a keypress to the sequencer. Returns true if there is one match or more, or false if there is no match.
+//
+// Digits typed before a key sequence are accumulated into a count,
+// unless a key sequence starting with that digit is bound.
 func (s *Sequencer) KeyInput(ev *tcell.EventKey, contexts []string) bool {
 	log.Debugf("Key event: %s", keysequence.FormatKey(ev))
+	if s.countInput(ev, contexts) {
+		return true
+	}
 	s.input = append(s.input, ev)
 	if len(s.findAll(s.input, contexts)) == 0 {
-		s.input = make(keysequence.KeySequence, 0)
+		s.reset()
 		return false
 	}
 	return true
 }
 
-// String returns the current input sequence as a string.
+// countInput adds a digit to the count, and returns true if the key was used for counting.
+func (s *Sequencer) countInput(ev *tcell.EventKey, contexts []string) bool {
+	if len(s.input) > 0 || ev.Key() != tcell.KeyRune || ev.Modifiers() != tcell.ModNone {
+		return false
+	}
+
+	r := ev.Rune()
+	if r < '0' || r > '9' || (r == '0' && s.count == 0) {
+		return false
+	}
+
+	if s.count == 0 && len(s.findAll(keysequence.KeySequence{ev}, contexts)) > 0 {
+		return false
+	}
+
+	s.count = s.count*10 + int(r-'0')
+
+	return true
+}
+
+// reset clears the input sequence and count.
+func (s *Sequencer) reset() {
+	s.count = 0
+	s.input = make(keysequence.KeySequence, 0)
+}
+
+// String returns the current count and input sequence as a string.
 func (s *Sequencer) String() string {
+	if s.count > 0 {
+		return strconv.Itoa(s.count) + s.input.String()
+	}
 	return s.input.String()
 }
 
@@ -116,6 +158,52 @@ func (s *Sequencer) uniq(bindings []Binding, contexts []string) *Binding {
 	return nil
 }
 
+// Pending returns all key bindings that start with the current input sequence, sorted by key sequence.
+// If a key sequence is bound in several contexts, only the binding in the most specific context is returned.
+// Returns nil if there is no input.
+func (s *Sequencer) Pending(contexts []string) []Binding {
+	if len(s.input) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	binds := make([]Binding, 0)
+	for _, context := range contexts {
+		for _, bind := range s.find(s.input, context) {
+			key := bind.Sequence.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			binds = append(binds, bind)
+		}
+	}
+
+	sort.Slice(binds, func(i, j int) bool {
+		return binds[i].Sequence.String() < binds[j].Sequence.String()
+	})
+
+	return binds
+}
+
+// Input returns the keys typed so far in the current input sequence.
+func (s *Sequencer) Input() keysequence.KeySequence {
+	return s.input
+}
+
+// Lookup returns the binding for a key sequence in the most specific context,
+// or nil if the sequence is not bound. The current input sequence is not affected.
+func (s *Sequencer) Lookup(seq keysequence.KeySequence, contexts []string) *Binding {
+	for _, context := range contexts {
+		for _, bind := range s.find(seq, context) {
+			if keysequence.Compare(bind.Sequence, seq) {
+				return &bind
+			}
+		}
+	}
+	return nil
+}
+
 // Match returns a key binding if the current input sequence is found.
 func (s *Sequencer) Match(contexts []string) *Binding {
 	binds := s.findAll(s.input, contexts)
@@ -126,6 +214,7 @@ func (s *Sequencer) Match(contexts []string) *Binding {
 	if !keysequence.Compare(bind.Sequence, s.input) {
 		return nil
 	}
-	s.input = make(keysequence.KeySequence, 0)
+	bind.Count = s.count
+	s.reset()
 	return bind
 }
@@ -105,4 +105,80 @@ func TestSequencer(t *testing.T) {
 		assert.NotNil(t, match)
 		assert.Equal(t, "foo bar", match.Command)
 	})
+	t.Run("pending key bindings", func(t *testing.T) {
+		sequencer := keys.NewSequencer()
+		contexts := []string{commands.TracklistContext, commands.GlobalContext}
+		other := keysequence.KeySequence{seq[0], tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)}
+
+		assert.NoError(t, sequencer.AddBind(keys.Binding{Sequence: seq, Command: "foo", Context: commands.GlobalContext}))
+		assert.NoError(t, sequencer.AddBind(keys.Binding{Sequence: seq, Command: "bar", Context: commands.TracklistContext}))
+		assert.NoError(t, sequencer.AddBind(keys.Binding{Sequence: other, Command: "baz", Context: commands.GlobalContext}))
+		assert.NoError(t, sequencer.AddBind(keys.Binding{Sequence: other, Command: "quux", Context: commands.LibraryContext}))
+
+		assert.Nil(t, sequencer.Pending(contexts))
+
+		sequencer.KeyInput(seq[0], contexts)
+		pending := sequencer.Pending(contexts)
+		assert.Len(t, pending, 2)
+		assert.Equal(t, "bar", pending[0].Command)
+		assert.Equal(t, "baz", pending[1].Command)
+
+		sequencer.KeyInput(seq[1], contexts)
+		pending = sequencer.Pending(contexts)
+		assert.Len(t, pending, 1)
+		assert.Equal(t, "bar", pending[0].Command)
+		assert.Len(t, sequencer.Input(), 2)
+	})
+}
+
+func TestSequencerCount(t *testing.T) {
+	sequencer := keys.NewSequencer()
+	contexts := []string{commands.GlobalContext}
+
+	err := sequencer.AddBind(keys.Binding{
+		Sequence: keysequence.KeySequence{tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone)},
+		Command:  "cursor down",
+		Context:  commands.GlobalContext,
+	})
+	assert.NoError(t, err)
+
+	for _, r := range "12j" {
+		sequencer.KeyInput(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), contexts)
+		if r != 'j' {
+			assert.Nil(t, sequencer.Match(contexts))
+		}
+	}
+
+	match := sequencer.Match(contexts)
+	if assert.NotNil(t, match) {
+		assert.Equal(t, "cursor down", match.Command)
+		assert.Equal(t, 12, match.Count)
+	}
+
+	// Count is reset after a match.
+	sequencer.KeyInput(tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone), contexts)
+	match = sequencer.Match(contexts)
+	if assert.NotNil(t, match) {
+		assert.Equal(t, 0, match.Count)
+	}
+}
+
+func TestSequencerLookup(t *testing.T) {
+	sequencer := keys.NewSequencer()
+	enter := keysequence.KeySequence{tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)}
+
+	assert.NoError(t, sequencer.AddBind(keys.Binding{Sequence: enter, Command: "show selected", Context: commands.GlobalContext}))
+	assert.NoError(t, sequencer.AddBind(keys.Binding{Sequence: enter, Command: "play selection", Context: commands.TracklistContext}))
+
+	bind := sequencer.Lookup(enter, []string{commands.TracklistContext, commands.GlobalContext})
+	if assert.NotNil(t, bind) {
+		assert.Equal(t, "play selection", bind.Command)
+	}
+
+	bind = sequencer.Lookup(enter, []string{commands.GlobalContext})
+	if assert.NotNil(t, bind) {
+		assert.Equal(t, "show selected", bind.Command)
+	}
+
+	assert.Nil(t, sequencer.Lookup(keysequence.KeySequence{}, []string{commands.GlobalContext}))
 }
@@ -2,6 +2,7 @@ package list
 
 import (
 	"sort"
+	"unicode/utf8"
 )
 
 type Item interface {
@@ -22,9 +23,9 @@ func (c *Column) sort() {
 }
 
 func (c *Column) Add(item string) {
-	ln := len(item)
+	ln := utf8.RuneCountInString(item)
 	c.lengths = append(c.lengths, ln)
-	c.total += len(item)
+	c.total += ln
 	c.sorted = false
 }
 
@@ -56,7 +57,8 @@ func (c *Column) Median() int {
 }
 
 func (c *Column) Remove(item string) {
-	idx := c.lengths.Search(len(item))
+	ln := utf8.RuneCountInString(item)
+	idx := c.lengths.Search(ln)
 	if idx >= c.lengths.Len() {
 		return
 	} else if idx == c.lengths.Len()-1 {
@@ -64,7 +66,7 @@ func (c *Column) Remove(item string) {
 	} else {
 		c.lengths = append(c.lengths[:idx], c.lengths[idx+1:]...)
 	}
-	c.total -= len(item)
+	c.total -= ln
 	c.sorted = false
 }
 
@@ -74,7 +76,7 @@ func (c *Column) Set(items []string) {
 	c.total = 0
 
 	for i, item := range items {
-		ln := len(item)
+		ln := utf8.RuneCountInString(item)
 		c.lengths[i] = ln
 		c.total += c.lengths[i]
 	}
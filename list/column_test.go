@@ -55,3 +55,15 @@ func TestColumnAddRemove(t *testing.T) {
 	assert.Equal(t, 5, column.Avg())
 	assert.Equal(t, 9, column.Max())
 }
+
+func TestColumnRuneWidth(t *testing.T) {
+	column := &list.Column{}
+	column.Add("Motörhead")
+	column.Add("▁▃▅▇")
+
+	assert.Equal(t, 9, column.Max())
+	assert.Equal(t, 6, column.Avg())
+
+	column.Remove("Motörhead")
+	assert.Equal(t, 4, column.Max())
+}
@@ -13,10 +13,13 @@ const (
 	DataTypeList                = "list"
 	DataTypeLogLine             = "logline"
 	DataTypeKeyBinding          = "keybinding"
+	DataTypeHelp                = "help"
 	DataTypeTrack               = "track"
 	DataTypeDevice              = "device"
 	DataTypeAlbum               = "album"
 	DataTypePlaylist            = "playlist"
+	DataTypeInfo                = "info"
+	DataTypeEpisode             = "episode"
 )
 
 type Row interface {
@@ -86,6 +89,8 @@ func (row *BaseRow) URI() spotify.URI {
 	case DataTypeTrack:
 	case DataTypeDevice:
 	case DataTypeAlbum:
+	case DataTypePlaylist:
+	case DataTypeEpisode:
 	default:
 		return ""
 	}
@@ -0,0 +1,31 @@
+package log
+
+import (
+	"io"
+)
+
+var console struct {
+	out   io.Writer
+	err   io.Writer
+	level Level
+}
+
+// SetConsole prints new messages up to the given level to the console, as plain
+// text without timestamps. Errors are printed to errw, other messages to outw.
+// Pass nil writers to disable console output.
+func SetConsole(outw, errw io.Writer, level Level) {
+	console.out = outw
+	console.err = errw
+	console.level = level
+}
+
+func writeConsole(msg Message) {
+	w := console.out
+	if msg.Level == ErrorLevel {
+		w = console.err
+	}
+	if w == nil || msg.Level > console.level {
+		return
+	}
+	_, _ = io.WriteString(w, msg.Text+"\n")
+}
@@ -26,6 +26,7 @@ func Logf(format string, level Level, args ...interface{}) (int, error) {
 		Text:      formatted,
 	}
 	appendMessage(msg)
+	writeConsole(msg)
 	return msg.Write(writer)
 }
 
@@ -1,6 +1,7 @@
 package log_test
 
 import (
+	"bytes"
 	"github.com/ambientsound/visp/log"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -33,3 +34,18 @@ func TestErrorf(t *testing.T) {
 	assert.Equal(t, "this is a string with 645", linebuffer[0].Text)
 	assert.Equal(t, log.ErrorLevel, linebuffer[0].Level)
 }
+
+func TestSetConsole(t *testing.T) {
+	out := &bytes.Buffer{}
+	errw := &bytes.Buffer{}
+
+	log.SetConsole(out, errw, log.InfoLevel)
+	defer log.SetConsole(nil, nil, log.ErrorLevel)
+
+	log.Infof("info message")
+	log.Errorf("error message")
+	log.Debugf("debug message")
+
+	assert.Equal(t, "info message\n", out.String())
+	assert.Equal(t, "error message\n", errw.String())
+}
@@ -1,6 +1,9 @@
 package multibar
 
 import (
+	"strings"
+	"unicode/utf8"
+
 	"github.com/ambientsound/visp/log"
 )
 
@@ -61,3 +64,16 @@ func (h *history) validateIndex() {
 		h.index = 0
 	}
 }
+
+// Search returns the index of the newest item, at or before index, that contains query,
+// and the position of the query within the item, counted in runes.
+// If no items match, the index returned is -1.
+func (h *history) Search(query string, index int) (int, int) {
+	for i := index; i >= 0 && i < len(h.items); i-- {
+		position := strings.Index(h.items[i], query)
+		if position >= 0 {
+			return i, utf8.RuneCountInString(h.items[i][:position])
+		}
+	}
+	return -1, 0
+}
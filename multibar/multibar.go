@@ -8,6 +8,7 @@
 package multibar
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -15,6 +16,7 @@ import (
 	"github.com/ambientsound/visp/log"
 
 	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/spotify/link"
 	"github.com/ambientsound/visp/utils"
 
 	"github.com/gdamore/tcell/v2"
@@ -26,18 +28,49 @@ type TabCompleter interface {
 
 type TabCompleterFactory func(input string) TabCompleter
 
+// killRingSize is the number of killed texts that are remembered.
+const killRingSize = 32
+
+// action is the kind of editing done by the previous key press.
+// Consecutive kills are joined, and yanked text can only be rotated right after yanking.
+type action int
+
+const (
+	actionNone action = iota
+	actionKill
+	actionYank
+)
+
 // Multibar implements a Vi-like combined statusbar and input box.
 type Multibar struct {
 	buffer      []rune
 	commands    chan string
 	cursor      int
+	edits       chan string
 	history     []*history
+	kills       []string
+	last        action
 	mode        InputMode
 	msg         string
 	orig        []rune
+	pasted      []rune
+	pasting     bool
+	prefix      bool
 	searches    chan string
 	tabComplete TabCompleter
 	tcf         TabCompleterFactory
+
+	// State of the last yank, so that it can be replaced with older kills.
+	yankIndex  int
+	yankLength int
+
+	// State of the incremental reverse history search.
+	reverse       bool
+	reverseFailed bool
+	reverseIndex  int
+	reverseQuery  []rune
+	reverseLast   string
+	reverseOrig   []rune
 }
 
 func New(tcf TabCompleterFactory) *Multibar {
@@ -50,6 +83,7 @@ func New(tcf TabCompleterFactory) *Multibar {
 		buffer:   make([]rune, 0),
 		orig:     make([]rune, 0),
 		commands: make(chan string, 16),
+		edits:    make(chan string, 1),
 		searches: make(chan string, 16),
 		tcf:      tcf,
 	}
@@ -57,17 +91,49 @@ func New(tcf TabCompleterFactory) *Multibar {
 
 // Input is called on keyboard events.
 func (m *Multibar) Input(event tcell.Event) bool {
+	if ev, ok := event.(*tcell.EventPaste); ok {
+		m.paste(ev)
+		return true
+	}
+
 	ev, ok := event.(*tcell.EventKey)
 	if !ok {
 		return false
 	}
 
+	// Pasted text is never interpreted as key bindings or editing commands.
+	if m.pasting {
+		m.pasteKey(ev)
+		return true
+	}
+
 	if m.mode == ModeNormal {
 		return false
 	}
 
 	log.Debugf("multibar keypress: name=%v key=%v modifiers=%v", ev.Name(), ev.Key(), ev.Modifiers())
 
+	// During a reverse history search, most keys edit the search query.
+	// Other keys end the search, and are then handled as usual.
+	if m.reverse && m.reverseSearchKey(ev) {
+		if m.mode == ModeSearch {
+			m.searches <- string(m.buffer)
+		}
+		return true
+	}
+
+	last := m.last
+	m.last = actionNone
+
+	// Ctrl-X starts a two-key sequence.
+	if m.prefix {
+		m.prefix = false
+		if ev.Key() == tcell.KeyCtrlE {
+			m.edit()
+			return true
+		}
+	}
+
 	switch ev.Key() {
 
 	// Alt keys has to be handled a bit differently than Ctrl keys.
@@ -82,11 +148,23 @@ func (m *Multibar) Input(event tcell.Event) bool {
 				m.wordJump(-1)
 			case 'f':
 				m.wordJump(1)
+			case 'd':
+				m.killWordForward(last)
+			case 'y':
+				m.yankPop(last)
 			}
 		}
 
 	case tcell.KeyCtrlU:
-		m.truncate()
+		m.truncate(last)
+	case tcell.KeyCtrlK:
+		m.killToEnd(last)
+	case tcell.KeyCtrlY:
+		m.yank()
+	case tcell.KeyCtrlR:
+		m.startReverseSearch()
+	case tcell.KeyCtrlX:
+		m.prefix = true
 	case tcell.KeyEnter:
 		m.finish()
 	case tcell.KeyTab:
@@ -114,7 +192,7 @@ func (m *Multibar) Input(event tcell.Event) bool {
 	case tcell.KeyBS, tcell.KeyDEL:
 		m.backspace()
 	case tcell.KeyCtrlW:
-		m.deleteWord()
+		m.deleteWord(last)
 
 	default:
 		log.Debugf("Unhandled text input event in Multibar: %v", ev.Key())
@@ -150,6 +228,9 @@ func (m *Multibar) SetMessage(msg string) {
 func (m *Multibar) SetMode(mode InputMode) {
 	log.Debugf("Switching input mode from %s to %s", m.mode, mode)
 	m.mode = mode
+	m.reverse = false
+	m.prefix = false
+	m.last = actionNone
 	m.setRunes(make([]rune, 0))
 	m.History().Reset("")
 }
@@ -158,6 +239,28 @@ func (m *Multibar) Mode() InputMode {
 	return m.mode
 }
 
+// SetText replaces the input text, and moves the cursor to the end.
+func (m *Multibar) SetText(s string) {
+	m.tabComplete = nil
+	m.setRunes([]rune(s))
+	m.cursor = len(m.buffer)
+	m.History().Reset(s)
+}
+
+// Prompt returns the text shown in front of the input text.
+func (m *Multibar) Prompt() string {
+	switch {
+	case m.reverse && m.reverseFailed:
+		return fmt.Sprintf("(failed reverse-i-search)`%s': ", string(m.reverseQuery))
+	case m.reverse:
+		return fmt.Sprintf("(reverse-i-search)`%s': ", string(m.reverseQuery))
+	case m.mode == ModeSearch:
+		return "/"
+	default:
+		return ":"
+	}
+}
+
 func (m *Multibar) String() string {
 	return string(m.buffer)
 }
@@ -181,6 +284,12 @@ func (m *Multibar) Searches() <-chan string {
 	return m.searches
 }
 
+// Edits returns a channel sending the input text when the user wants to edit it in an external editor.
+// The edited text should be given back with SetText.
+func (m *Multibar) Edits() <-chan string {
+	return m.edits
+}
+
 func (m *Multibar) setRunes(r []rune) {
 	m.buffer = r
 	m.validateCursor()
@@ -196,25 +305,156 @@ func (m *Multibar) validateCursor() {
 	}
 }
 
-func (m *Multibar) truncate() {
+// truncate deletes all text, and saves it in the kill ring.
+func (m *Multibar) truncate(last action) {
 	m.tabComplete = nil
+	m.kill(string(m.buffer), false, last)
 	m.setRunes(make([]rune, 0))
 	m.History().Reset(m.String())
 }
 
+// killToEnd deletes the text after the cursor, and saves it in the kill ring.
+func (m *Multibar) killToEnd(last action) {
+	m.tabComplete = nil
+	m.kill(string(m.buffer[m.cursor:]), false, last)
+	m.setRunes(m.buffer[:m.cursor])
+	m.History().Reset(m.String())
+}
+
+// killWordForward deletes the text up to the end of the next word, and saves it in the kill ring.
+func (m *Multibar) killWordForward(last action) {
+	m.tabComplete = nil
+	end := m.cursor + nextWord(m.buffer, m.cursor, 1)
+	m.kill(string(m.buffer[m.cursor:end]), false, last)
+	m.setRunes(deleteBackwards(m.buffer, end, end-m.cursor))
+	m.History().Reset(m.String())
+}
+
+// kill saves deleted text in the kill ring. If the previous key press also killed text,
+// the texts are joined, in the order they appeared in the input text.
+func (m *Multibar) kill(text string, backwards bool, last action) {
+	m.last = actionKill
+	if len(text) == 0 {
+		return
+	}
+	if last == actionKill && len(m.kills) > 0 {
+		top := len(m.kills) - 1
+		if backwards {
+			m.kills[top] = text + m.kills[top]
+		} else {
+			m.kills[top] += text
+		}
+		return
+	}
+	m.kills = append(m.kills, text)
+	if len(m.kills) > killRingSize {
+		m.kills = m.kills[1:]
+	}
+}
+
+// yank inserts the most recently killed text at the cursor position.
+func (m *Multibar) yank() {
+	if len(m.kills) == 0 {
+		return
+	}
+	m.yankIndex = len(m.kills) - 1
+	m.insertYank()
+}
+
+// yankPop replaces the text inserted by the previous yank with the kill before it.
+func (m *Multibar) yankPop(last action) {
+	if last != actionYank || len(m.kills) == 0 {
+		return
+	}
+	runes := deleteBackwards(m.buffer, m.cursor, m.yankLength)
+	m.cursor -= m.yankLength
+	m.setRunes(runes)
+	m.yankIndex = (m.yankIndex + len(m.kills) - 1) % len(m.kills)
+	m.insertYank()
+}
+
+func (m *Multibar) insertYank() {
+	runes := []rune(m.kills[m.yankIndex])
+	m.insert(runes)
+	m.yankLength = len(runes)
+	m.last = actionYank
+}
+
+// edit sends the input text to be edited in an external editor.
+func (m *Multibar) edit() {
+	if m.mode != ModeInput {
+		return
+	}
+	m.tabComplete = nil
+	select {
+	case m.edits <- m.String():
+	default:
+		log.Debugf("multibar: input text is already being edited")
+	}
+}
+
 // inputRune inserts a literal rune at the cursor position.
 func (m *Multibar) inputRune(r rune) {
+	m.insert([]rune{r})
+}
+
+// insert inserts literal text at the cursor position.
+func (m *Multibar) insert(r []rune) {
 	m.tabComplete = nil
-	runes := make([]rune, len(m.buffer)+1)
+	runes := make([]rune, len(m.buffer)+len(r))
 	copy(runes, m.buffer[:m.cursor])
-	copy(runes[m.cursor+1:], m.buffer[m.cursor:])
-	runes[m.cursor] = r
+	copy(runes[m.cursor:], r)
+	copy(runes[m.cursor+len(r):], m.buffer[m.cursor:])
 	m.setRunes(runes)
 
-	m.cursor++
+	m.cursor += len(r)
 	m.History().Reset(m.String())
 }
 
+// paste starts or ends a bracketed paste. Text pasted in normal mode is opened
+// if it is a Spotify link, and otherwise placed on the command line.
+// Spotify links pasted into an empty command line are prefixed with `open`.
+func (m *Multibar) paste(ev *tcell.EventPaste) {
+	if ev.Start() {
+		m.pasting = true
+		m.pasted = make([]rune, 0)
+		return
+	}
+
+	m.pasting = false
+	text := strings.Join(strings.Fields(string(m.pasted)), " ")
+	if len(text) == 0 {
+		return
+	}
+
+	lnk, err := spotify_link.Parse(text)
+	switch {
+	case m.mode == ModeNormal && err == nil:
+		m.commands <- "open " + string(lnk.URI())
+		return
+	case m.mode == ModeNormal:
+		m.SetMode(ModeInput)
+	case m.mode == ModeInput && len(m.buffer) == 0 && err == nil:
+		text = "open " + string(lnk.URI())
+	}
+
+	m.insert([]rune(text))
+
+	if m.mode == ModeSearch {
+		m.searches <- string(m.buffer)
+	}
+}
+
+// pasteKey collects a key press that is part of pasted text. Line breaks and tabs are kept as whitespace.
+func (m *Multibar) pasteKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		m.pasted = append(m.pasted, ev.Rune())
+	case tcell.KeyEnter, tcell.KeyLF, tcell.KeyTab:
+		m.pasted = append(m.pasted, ' ')
+	}
+}
+
 // backspace deletes a literal rune behind the cursor position.
 func (m *Multibar) backspace() {
 
@@ -235,8 +475,8 @@ func (m *Multibar) backspace() {
 }
 
 // deleteWord deletes the previous word, along with all the backspace
-// succeeding it.
-func (m *Multibar) deleteWord() {
+// succeeding it. The deleted text is saved in the kill ring.
+func (m *Multibar) deleteWord(last action) {
 
 	m.tabComplete = nil
 
@@ -260,6 +500,7 @@ func (m *Multibar) deleteWord() {
 	}
 
 	// Delete backwards.
+	m.kill(string(m.buffer[cursor:m.cursor]), true, last)
 	runes := deleteBackwards(m.buffer, m.cursor, m.cursor-cursor)
 	m.cursor = cursor
 	m.setRunes(runes)
@@ -307,6 +548,87 @@ func (m *Multibar) wordJump(offset int) {
 	m.validateCursor()
 }
 
+// startReverseSearch starts an incremental search backwards through the input history.
+func (m *Multibar) startReverseSearch() {
+	m.tabComplete = nil
+	m.reverse = true
+	m.reverseFailed = false
+	m.reverseIndex = len(m.History().items)
+	m.reverseQuery = make([]rune, 0)
+	m.reverseOrig = make([]rune, len(m.buffer))
+	copy(m.reverseOrig, m.buffer)
+}
+
+// reverseSearchKey handles a key press during a reverse history search.
+// Returns false if the key ends the search, and should be handled as usual.
+func (m *Multibar) reverseSearchKey(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		if ev.Modifiers()&tcell.ModAlt != 0 {
+			break
+		}
+		m.reverseQuery = append(m.reverseQuery, ev.Rune())
+		m.reverseSearch(m.reverseIndex)
+		return true
+
+	case tcell.KeyBS, tcell.KeyDEL:
+		if len(m.reverseQuery) > 0 {
+			m.reverseQuery = m.reverseQuery[:len(m.reverseQuery)-1]
+		}
+		if len(m.reverseQuery) == 0 {
+			m.reverseFailed = false
+			m.reverseIndex = len(m.History().items)
+			m.setRunes(m.reverseOrig)
+			m.cursor = len(m.buffer)
+			return true
+		}
+		m.reverseSearch(len(m.History().items) - 1)
+		return true
+
+	// Pressing Ctrl-R again finds the next older match, or repeats the last search.
+	case tcell.KeyCtrlR:
+		if len(m.reverseQuery) == 0 {
+			m.reverseQuery = []rune(m.reverseLast)
+		}
+		if len(m.reverseQuery) > 0 {
+			m.reverseSearch(m.reverseIndex - 1)
+		}
+		return true
+
+	case tcell.KeyCtrlG, tcell.KeyCtrlC:
+		m.reverse = false
+		m.setRunes(m.reverseOrig)
+		m.cursor = len(m.buffer)
+		return true
+	}
+
+	// Any other key accepts the match.
+	m.reverse = false
+	m.reverseLast = string(m.reverseQuery)
+	m.History().Reset(m.String())
+	return false
+}
+
+// reverseSearch shows the newest history item at or before index that contains the search query.
+// If no item matches, the input text is left as it is.
+func (m *Multibar) reverseSearch(index int) {
+	query := string(m.reverseQuery)
+	if index >= len(m.History().items) {
+		index = len(m.History().items) - 1
+	}
+
+	found, position := m.History().Search(query, index)
+	if found < 0 {
+		m.reverseFailed = true
+		return
+	}
+
+	m.reverseFailed = false
+	m.reverseIndex = found
+	m.setRunes([]rune(m.History().items[found]))
+	m.cursor = position
+}
+
 // tab invokes tab completion.
 func (m *Multibar) tab() {
 
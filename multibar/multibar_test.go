@@ -0,0 +1,196 @@
+package multibar_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/multibar"
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// paste sends text to the multibar as a bracketed paste.
+func paste(m *multibar.Multibar, text string) {
+	m.Input(tcell.NewEventPaste(true))
+	for _, r := range text {
+		if r == '\n' {
+			m.Input(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+		} else {
+			m.Input(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		}
+	}
+	m.Input(tcell.NewEventPaste(false))
+}
+
+func TestPasteLinkInNormalMode(t *testing.T) {
+	m := multibar.New(nil)
+
+	paste(m, "https://open.spotify.com/album/1DFixLWuPkv3KT3TnV35m3?si=abc\n")
+
+	assert.Equal(t, multibar.ModeNormal, m.Mode())
+	assert.Equal(t, "open spotify:album:1DFixLWuPkv3KT3TnV35m3", <-m.Commands())
+}
+
+func TestPasteTextInNormalMode(t *testing.T) {
+	m := multibar.New(nil)
+
+	// Pasted keys are not passed on as key bindings.
+	paste(m, "jjdd")
+
+	assert.Equal(t, multibar.ModeInput, m.Mode())
+	assert.Equal(t, "jjdd", m.String())
+}
+
+func TestPasteInInputMode(t *testing.T) {
+	m := multibar.New(nil)
+	m.SetMode(multibar.ModeInput)
+
+	paste(m, "spotify:artist:0OdUWJ0sBjDrqHygGUXeCF")
+	assert.Equal(t, "open spotify:artist:0OdUWJ0sBjDrqHygGUXeCF", m.String())
+
+	m.SetMode(multibar.ModeInput)
+	m.Input(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone))
+	m.Input(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+	paste(m, "list goto\nfoo")
+	assert.Equal(t, "list goto foox", m.String())
+	assert.Equal(t, 13, m.Cursor())
+}
+
+// typeKeys sends literal text to the multibar, one key press per rune.
+func typeKeys(m *multibar.Multibar, text string) {
+	for _, r := range text {
+		m.Input(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+}
+
+func press(m *multibar.Multibar, key tcell.Key) {
+	m.Input(tcell.NewEventKey(key, 0, tcell.ModNone))
+}
+
+func alt(m *multibar.Multibar, r rune) {
+	m.Input(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModAlt))
+}
+
+// run enters a command in input mode, so that it is added to the history.
+func run(m *multibar.Multibar, command string) {
+	m.SetMode(multibar.ModeInput)
+	typeKeys(m, command)
+	press(m, tcell.KeyEnter)
+	<-m.Commands()
+}
+
+func TestWordMotions(t *testing.T) {
+	m := multibar.New(nil)
+	m.SetMode(multibar.ModeInput)
+	typeKeys(m, "list goto foo")
+
+	alt(m, 'b')
+	assert.Equal(t, 10, m.Cursor())
+	alt(m, 'b')
+	assert.Equal(t, 5, m.Cursor())
+	alt(m, 'f')
+	assert.Equal(t, 9, m.Cursor())
+}
+
+func TestKillRing(t *testing.T) {
+	m := multibar.New(nil)
+	m.SetMode(multibar.ModeInput)
+	typeKeys(m, "list goto foo bar")
+
+	// Consecutive kills are joined.
+	press(m, tcell.KeyCtrlW)
+	press(m, tcell.KeyCtrlW)
+	assert.Equal(t, "list goto ", m.String())
+
+	press(m, tcell.KeyCtrlA)
+	alt(m, 'f')
+	press(m, tcell.KeyCtrlK)
+	assert.Equal(t, "list", m.String())
+
+	press(m, tcell.KeyCtrlY)
+	assert.Equal(t, "list goto ", m.String())
+	assert.Equal(t, 10, m.Cursor())
+
+	// Alt-Y replaces the yanked text with older kills.
+	alt(m, 'y')
+	assert.Equal(t, "listfoo bar", m.String())
+	alt(m, 'y')
+	assert.Equal(t, "list goto ", m.String())
+
+	// Alt-Y does nothing unless the previous key was a yank.
+	typeKeys(m, "x")
+	alt(m, 'y')
+	assert.Equal(t, "list goto x", m.String())
+
+	press(m, tcell.KeyCtrlA)
+	alt(m, 'd')
+	assert.Equal(t, " goto x", m.String())
+	press(m, tcell.KeyCtrlE)
+	press(m, tcell.KeyCtrlY)
+	assert.Equal(t, " goto xlist", m.String())
+}
+
+func TestReverseSearch(t *testing.T) {
+	m := multibar.New(nil)
+	run(m, "list goto foo")
+	run(m, "set columns=artist,title")
+	run(m, "list goto bar")
+
+	m.SetMode(multibar.ModeInput)
+	typeKeys(m, "sel")
+	press(m, tcell.KeyCtrlR)
+	assert.Equal(t, "(reverse-i-search)`': ", m.Prompt())
+
+	typeKeys(m, "goto")
+	assert.Equal(t, "(reverse-i-search)`goto': ", m.Prompt())
+	assert.Equal(t, "list goto bar", m.String())
+	assert.Equal(t, 5, m.Cursor())
+
+	press(m, tcell.KeyCtrlR)
+	assert.Equal(t, "list goto foo", m.String())
+
+	// No older matches.
+	press(m, tcell.KeyCtrlR)
+	assert.Equal(t, "list goto foo", m.String())
+	assert.Equal(t, "(failed reverse-i-search)`goto': ", m.Prompt())
+
+	// Aborting restores the original text.
+	press(m, tcell.KeyCtrlG)
+	assert.Equal(t, "sel", m.String())
+	assert.Equal(t, ":", m.Prompt())
+
+	// Other keys accept the match and are handled as usual.
+	press(m, tcell.KeyCtrlR)
+	typeKeys(m, "col")
+	press(m, tcell.KeyCtrlE)
+	assert.Equal(t, ":", m.Prompt())
+	assert.Equal(t, 24, m.Cursor())
+
+	press(m, tcell.KeyEnter)
+	assert.Equal(t, "set columns=artist,title", <-m.Commands())
+
+	// Ctrl-R without a query repeats the last search.
+	m.SetMode(multibar.ModeInput)
+	press(m, tcell.KeyCtrlR)
+	press(m, tcell.KeyCtrlR)
+	assert.Equal(t, "set columns=artist,title", m.String())
+}
+
+func TestEditInExternalEditor(t *testing.T) {
+	m := multibar.New(nil)
+	m.SetMode(multibar.ModeInput)
+	typeKeys(m, "list goto")
+
+	press(m, tcell.KeyCtrlX)
+	press(m, tcell.KeyCtrlE)
+	assert.Equal(t, "list goto", <-m.Edits())
+
+	m.SetText("list goto foo")
+	assert.Equal(t, "list goto foo", m.String())
+	assert.Equal(t, 13, m.Cursor())
+
+	// Ctrl-E without the Ctrl-X prefix moves to the end of the line.
+	press(m, tcell.KeyCtrlA)
+	press(m, tcell.KeyCtrlE)
+	assert.Equal(t, 13, m.Cursor())
+	assert.Len(t, m.Edits(), 0)
+}
@@ -9,6 +9,9 @@ import (
 
 // Option names.
 const (
+	AlbumArt          = "albumart"
+	AlbumArtProtocol  = "albumart.protocol"
+	AlbumArtWidth     = "albumart.width"
 	Database          = "database"
 	Center            = "center"
 	ColumnsAlbums     = "columns.albums"
@@ -17,17 +20,32 @@ const (
 	Device            = "device"
 	ExpandColumns     = "expandcolumns"
 	FullHeaderColumns = "fullheadercolumns"
+	HookDeviceChange  = "hook.devicechange"
+	HookSongChange    = "hook.songchange"
+	HookStateChange   = "hook.statechange"
+	HTTPAPI           = "httpapi"
+	HTTPAPIAddress    = "httpapi.address"
+	HTTPAPIToken      = "httpapi.token"
+	InfoPanel         = "infopanel"
+	InfoPanelWidth    = "infopanel.width"
 	Limit             = "limit"
 	LogFile           = "logfile"
 	LogOverwrite      = "logoverwrite"
+	Mouse             = "mouse"
+	Mpris             = "mpris"
 	PollInterval      = "pollinterval"
 	SearchDelay       = "searchdelay"
+	Socket            = "socket"
 	SortAlbums        = "sort.albums"
 	SortPlaylists     = "sort.playlists"
 	SortSearch        = "sort.search"
 	SortTracklists    = "sort.tracklists"
 	SpotifyAuthServer = "spotifyauthserver"
+	Statusline        = "statusline"
+	Tabbar            = "tabbar"
 	Topbar            = "topbar"
+	WhichKey          = "whichkey"
+	WhichKeyTimeout   = "whichkeytimeout"
 )
 
 // Option types.
@@ -44,6 +62,9 @@ var (
 // Initialize option types.
 // Default values must be defined in the Defaults string.
 func init() {
+	v.Set(AlbumArt, boolType)
+	v.Set(AlbumArtProtocol, stringType)
+	v.Set(AlbumArtWidth, intType)
 	v.Set(Center, boolType)
 	v.Set(ColumnsAlbums, stringType)
 	v.Set(ColumnsPlaylists, stringType)
@@ -52,17 +73,32 @@ func init() {
 	v.Set(Device, stringType)
 	v.Set(ExpandColumns, stringType)
 	v.Set(FullHeaderColumns, stringType)
+	v.Set(HookDeviceChange, stringType)
+	v.Set(HookSongChange, stringType)
+	v.Set(HookStateChange, stringType)
+	v.Set(HTTPAPI, boolType)
+	v.Set(HTTPAPIAddress, stringType)
+	v.Set(HTTPAPIToken, stringType)
+	v.Set(InfoPanel, boolType)
+	v.Set(InfoPanelWidth, intType)
 	v.Set(Limit, intType)
 	v.Set(LogFile, stringType)
 	v.Set(LogOverwrite, boolType)
+	v.Set(Mouse, boolType)
+	v.Set(Mpris, boolType)
 	v.Set(PollInterval, intType)
 	v.Set(SearchDelay, intType)
+	v.Set(Socket, stringType)
 	v.Set(SortAlbums, stringType)
 	v.Set(SortPlaylists, stringType)
 	v.Set(SortSearch, stringType)
 	v.Set(SortTracklists, stringType)
 	v.Set(SpotifyAuthServer, stringType)
+	v.Set(Statusline, stringType)
+	v.Set(Tabbar, boolType)
 	v.Set(Topbar, stringType)
+	v.Set(WhichKey, boolType)
+	v.Set(WhichKeyTimeout, intType)
 }
 
 // Methods for getting options from Viper.
@@ -80,6 +116,45 @@ func GetList(key string) []string {
 	return strings.Split(v.GetString(key), ",")
 }
 
+// Settings for individual table columns, configured with `column.<tag>.<setting>`.
+const (
+	ColumnAlign    = "align"
+	ColumnFormat   = "format"
+	ColumnTruncate = "truncate"
+	ColumnWidth    = "width"
+)
+
+const columnPrefix = "column."
+
+// ColumnOption returns the name of an option configuring a single column.
+func ColumnOption(tag, setting string) string {
+	return columnPrefix + tag + "." + setting
+}
+
+// IsColumnOption returns true if key configures a single column.
+// Column options don't have to be initialized before they can be set.
+func IsColumnOption(key string) bool {
+	if !strings.HasPrefix(key, columnPrefix) {
+		return false
+	}
+	i := strings.LastIndex(key, ".")
+	if i < len(columnPrefix) {
+		return false
+	}
+	switch key[i+1:] {
+	case ColumnAlign, ColumnFormat, ColumnTruncate, ColumnWidth:
+		return i > len(columnPrefix)
+	}
+	return false
+}
+
+// ColumnSetting splits a column option into its tag and setting.
+func ColumnSetting(key string) (tag, setting string) {
+	key = strings.TrimPrefix(key, columnPrefix)
+	i := strings.LastIndex(key, ".")
+	return key[:i], key[i+1:]
+}
+
 // Return a human-readable representation of an option.
 // This string can be used in a config file.
 func Print(key string, opt interface{}) string {
@@ -101,23 +176,45 @@ func Print(key string, opt interface{}) string {
 // Default configuration file.
 const Defaults string = `
 # Global options
+set noalbumart
+set albumart.protocol=auto
+set albumart.width=30
 set columns.albums=artist,album,year,type
 set columns.playlists=name,tracks,owner,public,collaborative
 set columns.tracklists=artist,title,track,album,year,time,popularity
 set database=memory
 set expandcolumns=logMessage,description,deviceName,name,artist,title,album
 set fullheadercolumns=logLevel,public,collaborative,deviceName,track,tracks,year,time,deviceType,active,restricted,volume
+set noinfopanel
+set infopanel.width=40
 set searchdelay=200
+set socket=
 set limit=50
+set mpris
 set nocenter
+set nomouse
 set pollinterval=10
 set sort.albums=album,date,artist
 set sort.playlists=name
 set sort.search=track,disc,album,year,albumArtist
 set sort.tracklists=track,disc,album,year,albumArtist
 set spotifyauthserver="https://visp.site"
+set notabbar
+set whichkey
+set whichkeytimeout=3000
+set statusline="|${if selection}${selection|count} selected, ${selection|time}  ${end}${readout}"
 set topbar="${tag|artist} - ${tag|title} $liked|$shortname $version|$elapsed $state $time;\\#${tag|track} ${tag|album}|${list|title} [${list|index}/${list|total}] ${synced}|$device $mode $volume;;"
 
+# Hooks
+set hook.devicechange=
+set hook.songchange=
+set hook.statechange=
+
+# HTTP API
+set nohttpapi
+set httpapi.address=localhost:8910
+set httpapi.token=
+
 # Logging
 set nologoverwrite
 set logfile=
@@ -138,6 +235,7 @@ style _id gray
 # Tracklist styles
 style currentSong black yellow
 style cursor black white
+style inactiveCursor black gray
 style header gray dim bold
 style selection gray blue
 
@@ -174,6 +272,18 @@ style topbar darkgray
 style version gray dim
 style volume green
 style liked green
+style nextSong gray
+style playbackContext teal
+style progressElapsed teal
+style progressRemaining darkgray
+
+# Info panel styles
+style infoKey gray
+
+# Tab bar styles
+style activeTab black white
+style tab gray
+style tabbar darkgray
 
 # Other styles
 style commandText default
@@ -182,8 +292,11 @@ style errorText black red
 style logLevel dim gray
 style logMessage dim gray
 style readout default
+style selectionSummary teal
 style searchText white bold
 style sequenceText teal
+style whichKey gray
+style paneSeparator darkgray
 style statusbar default
 style timestamp teal
 style visualText teal
@@ -227,6 +340,9 @@ bind global / inputmode search
 bind global <F3> inputmode search
 bind global v select visual
 bind global V select visual
+bind global . again
+bind global q macro record
+bind global @ macro play
 
 # Keyboard bindings: player and mixer
 bind tracklist <Enter> play selection
@@ -246,10 +362,11 @@ bind global s shuffle
 bind global c show library
 bind global C show clipboards
 bind global w show windows
-bind global <F1> show keybindings
+bind global <F1> help
 bind windows <Enter> show selected
 bind library <Enter> show selected
 bind clipboards <Enter> show selected
+bind help <Enter> show selected
 bind devices <Enter> device activate
 bind playlists <Enter> list open
 
@@ -265,6 +382,12 @@ bind global <C-w>d list duplicate
 bind global <C-w>c list new
 bind global <C-w>x list close
 bind global <C-w>h show history
+bind global <C-w>s pane split
+bind global <C-w>v pane vsplit
+bind global <C-w>q pane close
+bind global <C-w>o pane only
+bind global <C-w>w pane next
+bind global <C-w>p pane prev
 bind global <Tab> list last
 bind tracklist <C-j> isolate artist
 bind tracklist <C-t> isolate albumArtist album
@@ -274,6 +397,7 @@ bind global <C-a> select all
 bind global <C-c> select none
 bind tracklist <Delete> cut
 bind tracklist x cut
+bind tracklist dd cut
 bind tracklist y yank
 bind global Y yank current
 bind tracklist p paste after
@@ -0,0 +1,126 @@
+// Package albumart downloads album cover images and renders them in the terminal.
+//
+// Images are drawn either using the kitty graphics protocol, as sixels, or with
+// Unicode half-block characters for terminals that support neither.
+package albumart
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+
+	"github.com/zmb3/spotify/v2"
+)
+
+// Protocols for drawing images in the terminal.
+const (
+	Auto      = "auto"
+	Kitty     = "kitty"
+	Sixel     = "sixel"
+	HalfBlock = "halfblock"
+)
+
+// Protocols returns the names of all protocols that can be configured.
+func Protocols() []string {
+	return []string{Auto, Kitty, Sixel, HalfBlock}
+}
+
+// ValidProtocol returns an error if name is not one of the protocols returned by Protocols.
+func ValidProtocol(name string) error {
+	for _, protocol := range Protocols() {
+		if name == protocol {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported album art protocol '%s', try one of %s", name, strings.Join(Protocols(), ", "))
+}
+
+// Detect guesses the best protocol supported by the terminal, based on environment variables.
+func Detect() string {
+	term := os.Getenv("TERM")
+	program := os.Getenv("TERM_PROGRAM")
+	switch {
+	case len(os.Getenv("KITTY_WINDOW_ID")) > 0, strings.Contains(term, "kitty"):
+		return Kitty
+	case program == "WezTerm", program == "ghostty":
+		return Kitty
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"), strings.Contains(term, "sixel"):
+		return Sixel
+	default:
+		return HalfBlock
+	}
+}
+
+// ImageURL returns the URL of the largest cover image of a track's album,
+// or an empty string if there is none.
+func ImageURL(track *spotify.FullTrack) string {
+	if track == nil {
+		return ""
+	}
+	url := ""
+	size := 0
+	for _, img := range track.Album.Images {
+		if len(url) == 0 || img.Width > size {
+			url = img.URL
+			size = img.Width
+		}
+	}
+	return url
+}
+
+// Fit scales an image to fit within width x height pixels, keeping its aspect ratio.
+// Each pixel in the result is the average of the source pixels it covers.
+func Fit(img image.Image, width, height int) *image.RGBA {
+	src := img.Bounds()
+	sw, sh := src.Dx(), src.Dy()
+	if sw == 0 || sh == 0 || width <= 0 || height <= 0 {
+		return image.NewRGBA(image.Rect(0, 0, 0, 0))
+	}
+
+	w, h := width, sh*width/sw
+	if h > height {
+		w, h = sw*height/sh, height
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := span(y, h, sh)
+		for x := 0; x < w; x++ {
+			x0, x1 := span(x, w, sw)
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(src.Min.X+sx, src.Min.Y+sy).RGBA()
+					r, g, b, a = r+cr, g+cg, b+cb, a+ca
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
+
+// span returns the range of source pixels covered by destination pixel i.
+func span(i, dst, src int) (int, int) {
+	start := i * src / dst
+	end := (i + 1) * src / dst
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
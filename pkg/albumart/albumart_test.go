@@ -0,0 +1,149 @@
+package albumart_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ambientsound/visp/pkg/albumart"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+)
+
+func solid(width, height int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestFit(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+
+	// Aspect ratio is preserved.
+	img := albumart.Fit(solid(640, 320, red), 30, 30)
+	assert.Equal(t, image.Rect(0, 0, 30, 15), img.Bounds())
+	assert.Equal(t, red, img.RGBAAt(10, 10))
+
+	img = albumart.Fit(solid(100, 100, red), 40, 20)
+	assert.Equal(t, image.Rect(0, 0, 20, 20), img.Bounds())
+
+	// Pixels are averaged.
+	checkers := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	checkers.Set(0, 0, color.RGBA{R: 200, A: 255})
+	checkers.Set(1, 1, color.RGBA{R: 200, A: 255})
+	img = albumart.Fit(checkers, 1, 1)
+	assert.Equal(t, uint8(100), img.RGBAAt(0, 0).R)
+}
+
+// setenv sets an environment variable for the duration of a test.
+func setenv(t *testing.T, key, value string) {
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func TestDetect(t *testing.T) {
+	setenv(t, "KITTY_WINDOW_ID", "")
+	setenv(t, "TERM_PROGRAM", "")
+
+	setenv(t, "TERM", "xterm-kitty")
+	assert.Equal(t, albumart.Kitty, albumart.Detect())
+
+	setenv(t, "TERM", "foot")
+	assert.Equal(t, albumart.Sixel, albumart.Detect())
+
+	setenv(t, "TERM", "xterm-256color")
+	assert.Equal(t, albumart.HalfBlock, albumart.Detect())
+
+	setenv(t, "TERM_PROGRAM", "WezTerm")
+	assert.Equal(t, albumart.Kitty, albumart.Detect())
+}
+
+func TestImageURL(t *testing.T) {
+	assert.Equal(t, "", albumart.ImageURL(nil))
+
+	track := &spotify.FullTrack{}
+	track.Album.Images = []spotify.Image{
+		{URL: "small", Width: 64},
+		{URL: "large", Width: 640},
+		{URL: "medium", Width: 300},
+	}
+	assert.Equal(t, "large", albumart.ImageURL(track))
+}
+
+func TestEncodeSixel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := albumart.EncodeSixel(buf, solid(8, 6, color.RGBA{R: 255, A: 255}))
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "\x1bP0;1;0q\"1;1;8;6"))
+	assert.True(t, strings.HasSuffix(out, "\x1b\\"))
+
+	// Pure red is color 180 in the palette, and all six rows of the band are set.
+	assert.Contains(t, out, "#180;2;100;0;0")
+	assert.Contains(t, out, "#180!8~$-")
+}
+
+func TestEncodeKitty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := albumart.EncodeKitty(buf, solid(20, 20, color.RGBA{G: 255, A: 255}), 10, 5)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "\x1b_Ga=T,f=100,q=2,C=1,c=10,r=5,m=0;"))
+	assert.True(t, strings.HasSuffix(out, "\x1b\\"))
+
+	// Large images are split into several chunks.
+	noise := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	rand.New(rand.NewSource(1)).Read(noise.Pix)
+	buf.Reset()
+	err = albumart.EncodeKitty(buf, noise, 10, 5)
+	require.NoError(t, err)
+
+	out = buf.String()
+	assert.True(t, strings.HasPrefix(out, "\x1b_Ga=T,f=100,q=2,C=1,c=10,r=5,m=1;"))
+	assert.Contains(t, out, "\x1b_Gm=0;")
+}
+
+func TestCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = png.Encode(w, solid(4, 4, color.White))
+	}))
+	defer server.Close()
+
+	cache := albumart.NewCache(t.TempDir())
+
+	for i := 0; i < 2; i++ {
+		img, err := cache.Get(context.Background(), server.URL+"/cover")
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 4, 4), img.Bounds())
+	}
+
+	// The second request is served from disk.
+	assert.Equal(t, 1, requests)
+
+	_, err := cache.Get(context.Background(), server.URL+"/missing\x00")
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package albumart
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ambientsound/visp/xdg"
+)
+
+const requestTimeout = 10 * time.Second
+
+// Cache downloads images, and keeps a copy of them on disk.
+type Cache struct {
+	dir string
+}
+
+// DefaultDir returns the directory where album art is cached.
+func DefaultDir() string {
+	return filepath.Join(xdg.CacheDirectory(), "albumart")
+}
+
+// NewCache returns a cache storing images in dir.
+func NewCache(dir string) *Cache {
+	return &Cache{
+		dir: dir,
+	}
+}
+
+// Get returns the image at url, downloading it only if it is not already cached.
+func (c *Cache) Get(ctx context.Context, url string) (image.Image, error) {
+	sum := sha1.Sum([]byte(url))
+	path := filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		data, err = download(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		err = os.MkdirAll(c.dir, 0755)
+		if err == nil {
+			err = ioutil.WriteFile(path, data, 0644)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cache album art: %s", err)
+		}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode album art: %s", err)
+	}
+
+	return img, nil
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download album art: %s", resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,16 @@
+package albumart
+
+// Cell dimensions in pixels, used when the terminal does not report its size in pixels.
+const (
+	defaultCellWidth  = 8
+	defaultCellHeight = 16
+)
+
+// CellSize returns the width and height of a terminal cell in pixels.
+func CellSize() (int, int) {
+	width, height := cellSize()
+	if width <= 0 || height <= 0 {
+		return defaultCellWidth, defaultCellHeight
+	}
+	return width, height
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+// +build !windows
+
+package albumart
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// cellSize asks the terminal for its size in pixels and cells.
+func cellSize() (int, int) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 || ws.Row == 0 {
+		return 0, 0
+	}
+	return int(ws.Xpixel / ws.Col), int(ws.Ypixel / ws.Row)
+}
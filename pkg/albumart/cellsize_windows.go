@@ -0,0 +1,6 @@
+package albumart
+
+// cellSize is not supported on Windows.
+func cellSize() (int, int) {
+	return 0, 0
+}
@@ -0,0 +1,127 @@
+package albumart
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+)
+
+// kittyChunkSize is the maximum payload size of a single kitty graphics escape sequence.
+const kittyChunkSize = 4096
+
+// MoveTo returns the escape sequence that moves the terminal cursor to a zero-based cell position.
+func MoveTo(x, y int) string {
+	return fmt.Sprintf("\x1b[%d;%dH", y+1, x+1)
+}
+
+// EncodeKitty writes an image at the cursor position using the kitty graphics protocol,
+// scaled to cover cols x rows cells. The cursor is not moved.
+func EncodeKitty(w io.Writer, img image.Image, cols, rows int) error {
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, img)
+	if err != nil {
+		return err
+	}
+
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+	out := bufio.NewWriter(w)
+
+	for i := 0; i == 0 || i < len(data); i += kittyChunkSize {
+		end := i + kittyChunkSize
+		more := 1
+		if end >= len(data) {
+			end = len(data)
+			more = 0
+		}
+		if i == 0 {
+			fmt.Fprintf(out, "\x1b_Ga=T,f=100,q=2,C=1,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, data[i:end])
+		} else {
+			fmt.Fprintf(out, "\x1b_Gm=%d;%s\x1b\\", more, data[i:end])
+		}
+	}
+
+	return out.Flush()
+}
+
+// ClearKitty removes all images drawn with the kitty graphics protocol.
+func ClearKitty(w io.Writer) error {
+	_, err := io.WriteString(w, "\x1b_Ga=d,q=2\x1b\\")
+	return err
+}
+
+// EncodeSixel writes an image at the cursor position as sixels, in its original size.
+// Colors are reduced to a palette of 216 colors.
+func EncodeSixel(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	for i := 0; i < 216; i++ {
+		fmt.Fprintf(out, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+	}
+
+	colors := make([]int, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			colors[y*width+x] = paletteIndex(r)*36 + paletteIndex(g)*6 + paletteIndex(b)
+		}
+	}
+
+	line := make([]byte, width)
+	for band := 0; band < height; band += 6 {
+		used := make(map[int]bool)
+		for y := band; y < band+6 && y < height; y++ {
+			for x := 0; x < width; x++ {
+				used[colors[y*width+x]] = true
+			}
+		}
+		for c := 0; c < 216; c++ {
+			if !used[c] {
+				continue
+			}
+			for x := 0; x < width; x++ {
+				bits := 0
+				for k := 0; k < 6 && band+k < height; k++ {
+					if colors[(band+k)*width+x] == c {
+						bits |= 1 << k
+					}
+				}
+				line[x] = byte(63 + bits)
+			}
+			fmt.Fprintf(out, "#%d", c)
+			writeRuns(out, line)
+			out.WriteByte('$')
+		}
+		out.WriteByte('-')
+	}
+
+	out.WriteString("\x1b\\")
+	return out.Flush()
+}
+
+// paletteIndex maps a 16-bit color component to one of six levels.
+func paletteIndex(c uint32) int {
+	return int((c>>8)*5+127) / 255
+}
+
+// writeRuns writes sixel characters, compressing repeated characters.
+func writeRuns(out *bufio.Writer, line []byte) {
+	for i := 0; i < len(line); {
+		j := i
+		for j < len(line) && line[j] == line[i] {
+			j++
+		}
+		if n := j - i; n > 3 {
+			fmt.Fprintf(out, "!%d%c", n, line[i])
+		} else {
+			out.Write(line[i:j])
+		}
+		i = j
+	}
+}
@@ -0,0 +1,125 @@
+// Package colorscheme finds and reads colorscheme files.
+//
+// A colorscheme file contains `style` lines, in the same format as the configuration file.
+// Colorschemes are looked up by name in the `colors` subdirectory of the XDG configuration
+// and data directories, and in the set of schemes bundled with Visp.
+package colorscheme
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/xdg"
+)
+
+const (
+	// DirName is the subdirectory where colorscheme files are stored.
+	DirName = "colors"
+
+	// Extension is the file name extension of colorscheme files.
+	Extension = ".conf"
+
+	// Default is the name of the colorscheme that restores the default styles.
+	Default = "default"
+)
+
+//go:embed schemes/*.conf
+var bundled embed.FS
+
+// Dirs returns the directories searched for colorscheme files, most important first.
+func Dirs() []string {
+	configDirs := xdg.ConfigDirectories()
+	dirs := make([]string, 0, len(configDirs)+1)
+	for i := len(configDirs) - 1; i >= 0; i-- {
+		dirs = append(dirs, filepath.Join(configDirs[i], DirName))
+	}
+	return append(dirs, filepath.Join(xdg.DataDirectory(), DirName))
+}
+
+// Names returns the names of all colorschemes found in dirs, including the bundled schemes, sorted.
+func Names(dirs []string) []string {
+	seen := map[string]bool{Default: true}
+	names := []string{Default}
+
+	add := func(file string) {
+		if !strings.HasSuffix(file, Extension) {
+			return
+		}
+		name := strings.TrimSuffix(file, Extension)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			if !file.IsDir() {
+				add(file.Name())
+			}
+		}
+	}
+
+	files, _ := bundled.ReadDir("schemes")
+	for _, file := range files {
+		add(file.Name())
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Open returns the contents of the named colorscheme.
+// Files in dirs take precedence over the bundled schemes, in the order given.
+func Open(name string, dirs []string) (io.ReadCloser, error) {
+	if len(name) == 0 || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid colorscheme name '%s'", name)
+	}
+
+	fileName := name + Extension
+	for _, dir := range dirs {
+		file, err := os.Open(filepath.Join(dir, fileName))
+		if err == nil {
+			return file, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	file, err := bundled.Open("schemes/" + fileName)
+	if err == nil {
+		return file, nil
+	}
+
+	if name == Default {
+		return ioutil.NopCloser(strings.NewReader(defaultStyles())), nil
+	}
+
+	return nil, fmt.Errorf("colorscheme '%s' not found", name)
+}
+
+// defaultStyles returns the style lines from the default configuration.
+func defaultStyles() string {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(strings.NewReader(options.Defaults))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "style ") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,56 @@
+package colorscheme_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ambientsound/visp/pkg/colorscheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "visp-colors")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "custom.conf"), []byte("style artist red\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a scheme\n"), 0644))
+
+	names := colorscheme.Names([]string{dir, filepath.Join(dir, "nonexist")})
+	assert.Equal(t, []string{"custom", "default", "gruvbox", "mono", "nord", "solarized"}, names)
+}
+
+func TestOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "visp-colors")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "nord.conf"), []byte("style artist red\n"), 0644))
+
+	read := func(name string) string {
+		file, err := colorscheme.Open(name, []string{dir})
+		require.NoError(t, err)
+		defer file.Close()
+		data, err := ioutil.ReadAll(file)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	// User files take precedence over bundled schemes.
+	assert.Equal(t, "style artist red\n", read("nord"))
+	assert.Contains(t, read("gruvbox"), "style artist @fabd2f")
+
+	// The default scheme is read from the default configuration.
+	assert.Contains(t, read("default"), "style artist yellow")
+	assert.False(t, strings.Contains(read("default"), "bind "))
+
+	_, err = colorscheme.Open("nonexist", []string{dir})
+	assert.Error(t, err)
+
+	_, err = colorscheme.Open("../nord", []string{dir})
+	assert.Error(t, err)
+}
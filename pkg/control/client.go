@@ -0,0 +1,40 @@
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+const dialTimeout = time.Second * 2
+
+// Send connects to a control socket, sends a single command line,
+// and returns the server response.
+func Send(path, line string) (*Response, error) {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("connect to Visp: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = fmt.Fprintln(conn, line)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	data, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	resp := &Response{}
+	err = json.Unmarshal(data, resp)
+	if err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,160 @@
+// Package control implements a local control socket, which allows external
+// programs such as scripts and window manager hotkeys to run commands in a
+// running Visp instance.
+//
+// The protocol is line based. Clients send one command per line, and the
+// server answers each line with a single line of JSON encoding a Response.
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/xdg"
+)
+
+const (
+	SocketFileName = "visp.sock"
+
+	// StatusQuery is answered with the current player state instead of being
+	// executed as a command.
+	StatusQuery = "status"
+
+	requestTimeout = time.Second * 10
+)
+
+// Request is a single command line received on the control socket.
+// Exactly one Response must be sent on the Reply channel.
+type Request struct {
+	Line  string
+	Reply chan Response
+}
+
+// Response is sent back to the client for every request.
+type Response struct {
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Server accepts connections on a Unix domain socket, and forwards
+// incoming command lines on the Requests channel.
+type Server struct {
+	listener net.Listener
+	path     string
+	requests chan Request
+}
+
+// DefaultPath returns the default location of the control socket.
+func DefaultPath() string {
+	return filepath.Join(xdg.RuntimeDirectory(), SocketFileName)
+}
+
+// Listen creates the control socket at the given path. If a stale socket file
+// is found, it is removed. If another program is listening on the socket,
+// an error is returned.
+func Listen(path string) (*Server, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = os.Stat(path); err == nil {
+		conn, err := net.Dial("unix", path)
+		if err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("socket %s is already in use by another process", path)
+		}
+		log.Debugf("Removing stale control socket %s", path)
+		err = os.Remove(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		listener: listener,
+		path:     path,
+		requests: make(chan Request),
+	}, nil
+}
+
+// Path returns the file system path of the socket.
+func (s *Server) Path() string {
+	return s.path
+}
+
+// Requests returns a channel sending any requests received from clients.
+func (s *Server) Requests() <-chan Request {
+	return s.requests
+}
+
+// Serve accepts connections until the server is closed.
+func (s *Server) Serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		} else if err != nil {
+			log.Errorf("Control socket: %s", err)
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// Close stops listening and removes the socket file.
+func (s *Server) Close() error {
+	return s.listener.Close()
+}
+
+// handle reads command lines from a single client connection and writes
+// back one response per line.
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		log.Debugf("Control socket received: %s", line)
+		err := enc.Encode(s.request(line))
+		if err != nil {
+			log.Debugf("Control socket: write response: %s", err)
+			return
+		}
+	}
+}
+
+// request forwards a line to the request channel and waits for the response.
+func (s *Server) request(line string) Response {
+	req := Request{
+		Line:  line,
+		Reply: make(chan Response, 1),
+	}
+
+	select {
+	case s.requests <- req:
+	case <-time.After(requestTimeout):
+		return Response{Error: "timeout waiting for Visp to accept command"}
+	}
+
+	select {
+	case resp := <-req.Reply:
+		return resp
+	case <-time.After(requestTimeout):
+		return Response{Error: "timeout waiting for command to finish"}
+	}
+}
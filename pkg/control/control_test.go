@@ -0,0 +1,58 @@
+package control_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ambientsound/visp/pkg/control"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), control.SocketFileName)
+
+	server, err := control.Listen(path)
+	require.NoError(t, err)
+	defer server.Close()
+	go server.Serve()
+
+	// Answer requests the same way the main loop would.
+	go func() {
+		for req := range server.Requests() {
+			switch req.Line {
+			case control.StatusQuery:
+				req.Reply <- control.Response{Result: map[string]string{"state": "play"}}
+			case "fail":
+				req.Reply <- control.Response{Error: fmt.Sprintf("not a command: %s", req.Line)}
+			default:
+				req.Reply <- control.Response{}
+			}
+		}
+	}()
+
+	t.Run("commands without output return an empty response", func(t *testing.T) {
+		resp, err := control.Send(path, "pause")
+		require.NoError(t, err)
+		assert.Empty(t, resp.Error)
+		assert.Nil(t, resp.Result)
+	})
+
+	t.Run("errors are passed to the client", func(t *testing.T) {
+		resp, err := control.Send(path, "fail")
+		require.NoError(t, err)
+		assert.Equal(t, "not a command: fail", resp.Error)
+	})
+
+	t.Run("status query returns a result", func(t *testing.T) {
+		resp, err := control.Send(path, control.StatusQuery)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"state": "play"}, resp.Result)
+	})
+
+	t.Run("listening on a socket in use fails", func(t *testing.T) {
+		_, err := control.Listen(path)
+		assert.Error(t, err)
+	})
+}
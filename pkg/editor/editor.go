@@ -0,0 +1,63 @@
+// Package editor lets the user edit text in an external text editor.
+//
+// The editor is taken from the VISUAL or EDITOR environment variables, and falls back to vi.
+// The editor command is run by the shell, so that it may contain arguments.
+package editor
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	shell    = "/bin/sh"
+	fallback = "vi"
+)
+
+// Command returns the editor command configured by the user.
+func Command() string {
+	for _, key := range []string{"VISUAL", "EDITOR"} {
+		if command := strings.TrimSpace(os.Getenv(key)); len(command) > 0 {
+			return command
+		}
+	}
+	return fallback
+}
+
+// Edit writes text to a temporary file, runs the editor on it in the foreground, and returns the edited text.
+// The terminal must be released before calling Edit. Line breaks are replaced by spaces,
+// since the edited text is a single command line.
+func Edit(text string) (string, error) {
+	file, err := ioutil.TempFile("", "visp-command-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(text + "\n")
+	if err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(shell, "-c", Command()+` "$1"`, shell, file.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(strings.Fields(string(data)), " "), nil
+}
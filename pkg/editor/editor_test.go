@@ -0,0 +1,47 @@
+package editor_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ambientsound/visp/pkg/editor"
+	"github.com/stretchr/testify/assert"
+)
+
+func setenv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestCommand(t *testing.T) {
+	setenv(t, "VISUAL", "")
+	setenv(t, "EDITOR", "")
+	assert.Equal(t, "vi", editor.Command())
+
+	setenv(t, "EDITOR", "nano")
+	assert.Equal(t, "nano", editor.Command())
+
+	setenv(t, "VISUAL", "code --wait")
+	assert.Equal(t, "code --wait", editor.Command())
+}
+
+func TestEdit(t *testing.T) {
+	setenv(t, "VISUAL", "")
+	setenv(t, "EDITOR", `sed -i -e 's/goto/new/' -e '$a\
+foo'`)
+
+	text, err := editor.Edit("list goto bar")
+	assert.NoError(t, err)
+	assert.Equal(t, "list new bar foo", text)
+
+	setenv(t, "EDITOR", "false")
+	_, err = editor.Edit("list goto bar")
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Column alignments.
+const (
+	AlignLeft   = "left"
+	AlignRight  = "right"
+	AlignCenter = "center"
+)
+
+// Truncation styles, used when a value is wider than its column.
+const (
+	TruncateCut    = "cut"
+	TruncateEnd    = "end"
+	TruncateMiddle = "middle"
+	TruncateStart  = "start"
+)
+
+// ellipsis marks where text was removed from a truncated value.
+const ellipsis = '…'
+
+// ValidAlign returns an error if align is not a known alignment.
+func ValidAlign(align string) error {
+	switch align {
+	case "", AlignLeft, AlignRight, AlignCenter:
+		return nil
+	}
+	return fmt.Errorf("unknown column alignment '%s'; expected one of left, right, center", align)
+}
+
+// ValidTruncate returns an error if truncate is not a known truncation style.
+func ValidTruncate(truncate string) error {
+	switch truncate {
+	case "", TruncateCut, TruncateEnd, TruncateMiddle, TruncateStart:
+		return nil
+	}
+	return fmt.Errorf("unknown column truncation '%s'; expected one of cut, end, middle, start", truncate)
+}
+
+// Fit truncates or pads a value so that it is exactly width characters wide.
+func Fit(value string, width int, align, truncate string) []rune {
+	runes := Truncate([]rune(value), width, truncate)
+	pad := width - len(runes)
+	if pad <= 0 {
+		return runes
+	}
+
+	left := 0
+	switch align {
+	case AlignRight:
+		left = pad
+	case AlignCenter:
+		left = pad / 2
+	}
+
+	return []rune(strings.Repeat(" ", left) + string(runes) + strings.Repeat(" ", pad-left))
+}
+
+// Truncate shortens runes to at most width characters, using the given truncation style.
+func Truncate(runes []rune, width int, truncate string) []rune {
+	if len(runes) <= width {
+		return runes
+	}
+	if width <= 1 || truncate == TruncateCut || truncate == "" {
+		if width < 0 {
+			width = 0
+		}
+		return runes[:width]
+	}
+
+	keep := width - 1
+	out := make([]rune, 0, width)
+
+	switch truncate {
+	case TruncateStart:
+		out = append(out, ellipsis)
+		out = append(out, runes[len(runes)-keep:]...)
+	case TruncateMiddle:
+		head := (keep + 1) / 2
+		tail := keep - head
+		out = append(out, runes[:head]...)
+		out = append(out, ellipsis)
+		out = append(out, runes[len(runes)-tail:]...)
+	default:
+		out = append(out, runes[:keep]...)
+		out = append(out, ellipsis)
+	}
+
+	return out
+}
+
+// Width parses a column width, given either as a number of characters such as `40`,
+// or as a percentage of the total width such as `40%`. Returns zero if no width is set.
+func Width(spec string, total int) (int, error) {
+	if len(spec) == 0 {
+		return 0, nil
+	}
+	percent := strings.HasSuffix(spec, "%")
+	n, err := strconv.Atoi(strings.TrimSuffix(spec, "%"))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid column width '%s'; expected a number or a percentage", spec)
+	}
+	if percent {
+		return total * n / 100, nil
+	}
+	return n, nil
+}
@@ -0,0 +1,160 @@
+// Package format transforms field values for display, and fits them into table columns.
+// Formatting only changes how values are drawn; the stored values used for sorting are left as is.
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ambientsound/visp/utils"
+)
+
+// Formatter transforms a field value for display.
+type Formatter func(value string) string
+
+// formatters contains all named formatters that can be assigned to columns.
+var formatters = map[string]Formatter{
+	"duration": Duration,
+	"number":   Number,
+	"percent":  Percent,
+	"raw":      Raw,
+	"relative": Relative,
+}
+
+// now returns the current time, and is replaced in tests.
+var now = time.Now
+
+// Get returns the formatter with the given name. An empty name returns Raw.
+func Get(name string) (Formatter, error) {
+	if len(name) == 0 {
+		return Raw, nil
+	}
+	if f, ok := formatters[name]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown column format '%s'; expected one of %s", name, strings.Join(Names(), ", "))
+}
+
+// Names returns the names of all formatters, sorted.
+func Names() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Raw returns the value unchanged.
+func Raw(value string) string {
+	return value
+}
+
+// Duration formats a number of seconds, or a time such as `03:45`, as a compact clock time such as `3:45`.
+func Duration(value string) string {
+	secs, ok := ParseSeconds(value)
+	if !ok {
+		return value
+	}
+	return strings.TrimPrefix(utils.TimeString(secs), "0")
+}
+
+// ParseSeconds parses either an integer number of seconds, or colon-separated hours, minutes and seconds.
+func ParseSeconds(value string) (int, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+	secs := 0
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		secs = secs*60 + n
+	}
+	return secs, true
+}
+
+// Number groups the digits of an integer part in thousands, such as `1,234,567`.
+func Number(value string) string {
+	integer, fraction := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		integer, fraction = value[:i], value[i:]
+	}
+	sign := ""
+	if strings.HasPrefix(integer, "-") {
+		sign, integer = "-", integer[1:]
+	}
+	if _, err := strconv.ParseUint(integer, 10, 64); err != nil {
+		return value
+	}
+	for i := len(integer) - 3; i > 0; i -= 3 {
+		integer = integer[:i] + "," + integer[i:]
+	}
+	return sign + integer + fraction
+}
+
+// Percent formats a fraction such as `0.57` as `57%`.
+func Percent(value string) string {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	return fmt.Sprintf("%.0f%%", f*100)
+}
+
+// dateLayouts are the date formats recognized by Relative.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// Relative formats a date as the time passed since then, such as `3 days ago`.
+func Relative(value string) string {
+	for _, layout := range dateLayouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return since(now().Sub(t))
+		}
+	}
+	return value
+}
+
+// since returns a human readable description of a duration in the past.
+func since(d time.Duration) string {
+	const day = time.Hour * 24
+
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{day * 365, "year"},
+		{day * 30, "month"},
+		{day * 7, "week"},
+		{day, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+	}
+
+	if d < 0 {
+		return "in the future"
+	}
+
+	for _, unit := range units {
+		n := int(d / unit.size)
+		switch {
+		case n == 1:
+			return fmt.Sprintf("1 %s ago", unit.name)
+		case n > 1:
+			return fmt.Sprintf("%d %ss ago", n, unit.name)
+		}
+	}
+
+	return "just now"
+}
@@ -0,0 +1,101 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	f, err := Get("")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", f("foo"))
+
+	f, err = Get("number")
+	assert.NoError(t, err)
+	assert.Equal(t, "1,234", f("1234"))
+
+	_, err = Get("nonexist")
+	assert.Error(t, err)
+}
+
+func TestFormatters(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2020, 6, 15, 12, 0, 0, 0, time.Local)
+	}
+	defer func() {
+		now = time.Now
+	}()
+
+	tests := []struct {
+		format Formatter
+		input  string
+		output string
+	}{
+		{Duration, "225", "3:45"},
+		{Duration, "03:45", "3:45"},
+		{Duration, "01:02:03", "1:02:03"},
+		{Duration, "foo", "foo"},
+		{Number, "1234567", "1,234,567"},
+		{Number, "-1234.5", "-1,234.5"},
+		{Number, "123", "123"},
+		{Number, "foo", "foo"},
+		{Percent, "0.57", "57%"},
+		{Percent, "1", "100%"},
+		{Percent, "foo", "foo"},
+		{Relative, "2020-06-12", "3 days ago"},
+		{Relative, "2018", "2 years ago"},
+		{Relative, "2020-06-15 11:59:30", "just now"},
+		{Relative, "2021-01-01", "in the future"},
+		{Relative, "foo", "foo"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, test.format(test.input), "input: %s", test.input)
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		input    string
+		width    int
+		align    string
+		truncate string
+		output   string
+	}{
+		{"foo", 5, AlignLeft, TruncateCut, "foo  "},
+		{"foo", 5, AlignRight, TruncateCut, "  foo"},
+		{"foo", 6, AlignCenter, TruncateCut, " foo  "},
+		{"foobarbaz", 5, AlignLeft, TruncateCut, "fooba"},
+		{"foobarbaz", 5, AlignLeft, TruncateEnd, "foob…"},
+		{"foobarbaz", 5, AlignLeft, TruncateMiddle, "fo…az"},
+		{"foobarbaz", 5, AlignLeft, TruncateStart, "…rbaz"},
+		{"foobarbaz", 1, AlignLeft, TruncateEnd, "f"},
+		{"foo", 0, AlignLeft, TruncateEnd, ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, string(Fit(test.input, test.width, test.align, test.truncate)))
+	}
+}
+
+func TestWidth(t *testing.T) {
+	w, err := Width("", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, w)
+
+	w, err = Width("12", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, w)
+
+	w, err = Width("40%", 80)
+	assert.NoError(t, err)
+	assert.Equal(t, 32, w)
+
+	_, err = Width("foo", 80)
+	assert.Error(t, err)
+
+	_, err = Width("-1", 80)
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+// Package hooks runs external commands when the player changes state.
+//
+// Hooks are shell commands, executed with information about the
+// player and the current track exposed as environment variables.
+package hooks
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/player"
+)
+
+// Events that can trigger hooks.
+const (
+	SongChange   = "songchange"
+	StateChange  = "statechange"
+	DeviceChange = "devicechange"
+)
+
+const (
+	envPrefix = "VISP_"
+	shell     = "/bin/sh"
+)
+
+// Run executes a hook command in the background. The command is passed to
+// the shell, so that pipes and redirection can be used. Errors and
+// non-zero exit codes are logged, but otherwise ignored.
+func Run(event, command string, summary player.Summary) error {
+	if len(command) == 0 {
+		return nil
+	}
+
+	cmd := exec.Command(shell, "-c", command)
+	cmd.Env = append(os.Environ(), Environment(event, summary)...)
+
+	log.Debugf("Running %s hook: %s", event, command)
+
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := cmd.Wait()
+		if err != nil {
+			log.Errorf("Hook %s: %s", event, err)
+		}
+	}()
+
+	return nil
+}
+
+// Environment returns environment variables describing the player state.
+// All track fields are exported, e.g. the track field `albumArtist`
+// is exported as `VISP_ALBUM_ARTIST`.
+func Environment(event string, summary player.Summary) []string {
+	env := map[string]string{
+		"EVENT":    event,
+		"STATE":    summary.State,
+		"PROGRESS": strconv.Itoa(summary.Progress),
+		"DURATION": strconv.Itoa(summary.Duration),
+		"VOLUME":   strconv.Itoa(summary.Volume),
+		"SHUFFLE":  strconv.FormatBool(summary.Shuffle),
+		"REPEAT":   summary.Repeat,
+		"LIKED":    strconv.FormatBool(summary.Liked),
+		"DEVICE":   summary.Device,
+	}
+
+	for key, value := range summary.Track {
+		env[envName(key)] = value
+	}
+
+	vars := make([]string, 0, len(env))
+	for key, value := range env {
+		vars = append(vars, envPrefix+key+"="+value)
+	}
+
+	return vars
+}
+
+// envName converts a camel-cased field name into an environment variable name.
+func envName(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package hooks_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ambientsound/visp/pkg/hooks"
+	"github.com/ambientsound/visp/player"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var summary = player.Summary{
+	State:    player.StatePlay,
+	Progress: 1500,
+	Duration: 213000,
+	Device:   "Kitchen",
+	Track: map[string]string{
+		"artist":      "Rick Astley",
+		"albumArtist": "Rick Astley",
+		"title":       "Never Gonna Give You Up",
+		"uri":         "spotify:track:4uLU6hMCjMI75M1A2tKUQC",
+	},
+}
+
+func TestEnvironment(t *testing.T) {
+	env := hooks.Environment(hooks.SongChange, summary)
+
+	assert.Contains(t, env, "VISP_EVENT=songchange")
+	assert.Contains(t, env, "VISP_STATE=play")
+	assert.Contains(t, env, "VISP_PROGRESS=1500")
+	assert.Contains(t, env, "VISP_DEVICE=Kitchen")
+	assert.Contains(t, env, "VISP_ARTIST=Rick Astley")
+	assert.Contains(t, env, "VISP_ALBUM_ARTIST=Rick Astley")
+	assert.Contains(t, env, "VISP_TITLE=Never Gonna Give You Up")
+	assert.Contains(t, env, "VISP_URI=spotify:track:4uLU6hMCjMI75M1A2tKUQC")
+}
+
+func TestRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "output")
+
+	err := hooks.Run(hooks.SongChange, `echo "$VISP_ARTIST - $VISP_TITLE" > `+output, summary)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		data, err := os.ReadFile(output)
+		return err == nil && string(data) == "Rick Astley - Never Gonna Give You Up\n"
+	}, time.Second, time.Millisecond*10)
+}
@@ -0,0 +1,286 @@
+// Package httpapi implements an HTTP and WebSocket API for remote control
+// of Visp, e.g. from a phone on the local network.
+//
+// All requests must be authenticated with a token, given either in the
+// `Authorization: Bearer <token>` header or in the `token` query parameter.
+//
+//	GET  /api/state   player state
+//	GET  /api/list    active list, including all rows
+//	POST /api/exec    run a command, given as {"command": "..."}
+//	GET  /api/events  WebSocket stream of player and list events
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/log"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	stateURL  = "/api/state"
+	listURL   = "/api/list"
+	execURL   = "/api/exec"
+	eventsURL = "/api/events"
+
+	requestTimeout  = time.Second * 10
+	subscriberQueue = 64
+)
+
+// Event types sent on the event stream.
+const (
+	EventPlayer = "player"
+	EventList   = "list"
+)
+
+// Request is a function that must be run on the main thread.
+// Exactly one Response must be sent on the Reply channel.
+type Request struct {
+	Run   func(api.API) (interface{}, error)
+	Reply chan Response
+}
+
+// Response is returned to the HTTP client as JSON.
+type Response struct {
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Event is sent to all clients listening on the event stream.
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// ExecRequest is the request body of the exec endpoint.
+type ExecRequest struct {
+	Command string `json:"command"`
+}
+
+// Server serves the HTTP API.
+type Server struct {
+	listener    net.Listener
+	mutex       sync.Mutex
+	requests    chan Request
+	server      *http.Server
+	subscribers map[chan Event]struct{}
+	token       string
+}
+
+// New returns a server that authenticates clients with the given token.
+func New(token string) *Server {
+	s := &Server{
+		requests:    make(chan Request),
+		subscribers: make(map[chan Event]struct{}),
+		token:       token,
+	}
+	s.server = &http.Server{
+		Handler: s.Router(),
+	}
+	return s
+}
+
+// Listen opens a TCP listener on the given address.
+func (s *Server) Listen(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	return nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Serve accepts connections until the server is closed.
+func (s *Server) Serve() {
+	err := s.server.Serve(s.listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Errorf("HTTP API: %s", err)
+	}
+}
+
+// Close shuts down the server and disconnects all clients.
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+// Requests returns a channel sending functions that must be run on the main thread.
+func (s *Server) Requests() <-chan Request {
+	return s.requests
+}
+
+// Broadcast sends an event to all clients listening on the event stream.
+// Clients that are too slow to receive events will miss them.
+func (s *Server) Broadcast(typ string, data interface{}) {
+	ev := Event{
+		Type: typ,
+		Data: data,
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for events := range s.subscribers {
+		select {
+		case events <- ev:
+		default:
+			log.Debugf("HTTP API: dropping %s event for slow client", typ)
+		}
+	}
+}
+
+// Router returns the HTTP routes of the API.
+func (s *Server) Router() chi.Router {
+	router := chi.NewRouter()
+
+	router.Use(middleware.NoCache)
+	router.Use(s.authenticate)
+
+	router.Get(stateURL, s.serveState)
+	router.Get(listURL, s.serveList)
+	router.Post(execURL, s.serveExec)
+	router.Handle(eventsURL, websocket.Server{Handler: s.serveEvents})
+
+	return router
+}
+
+// authenticate rejects requests without a valid token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if header := r.Header.Get("Authorization"); len(header) > 0 {
+			token = strings.TrimPrefix(header, "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			render(w, http.StatusUnauthorized, Response{Error: "invalid token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) serveState(w http.ResponseWriter, r *http.Request) {
+	s.serve(w, func(a api.API) (interface{}, error) {
+		return a.PlayerStatus().Summary(), nil
+	})
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request) {
+	s.serve(w, func(a api.API) (interface{}, error) {
+		lst := a.List()
+		if lst == nil {
+			return nil, fmt.Errorf("no active list")
+		}
+		return NewListSummary(lst), nil
+	})
+}
+
+func (s *Server) serveExec(w http.ResponseWriter, r *http.Request) {
+	req := ExecRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		render(w, http.StatusBadRequest, Response{Error: fmt.Sprintf("decode request: %s", err)})
+		return
+	}
+
+	s.serve(w, func(a api.API) (interface{}, error) {
+		return nil, a.Exec(req.Command)
+	})
+}
+
+// serveEvents streams events to a WebSocket client until it disconnects.
+func (s *Server) serveEvents(ws *websocket.Conn) {
+	events := s.subscribe()
+	defer s.unsubscribe(events)
+
+	// Incoming data is ignored, but reading is required to detect when the client disconnects.
+	closed := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, ws)
+		close(closed)
+	}()
+
+	for {
+		select {
+		case ev := <-events:
+			err := websocket.JSON.Send(ws, ev)
+			if err != nil {
+				log.Debugf("HTTP API: send event: %s", err)
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// serve runs a function on the main thread and renders the result.
+func (s *Server) serve(w http.ResponseWriter, run func(api.API) (interface{}, error)) {
+	req := Request{
+		Run:   run,
+		Reply: make(chan Response, 1),
+	}
+
+	select {
+	case s.requests <- req:
+	case <-time.After(requestTimeout):
+		render(w, http.StatusServiceUnavailable, Response{Error: "timeout waiting for Visp to accept request"})
+		return
+	}
+
+	select {
+	case resp := <-req.Reply:
+		if len(resp.Error) > 0 {
+			render(w, http.StatusBadRequest, resp)
+		} else {
+			render(w, http.StatusOK, resp)
+		}
+	case <-time.After(requestTimeout):
+		render(w, http.StatusServiceUnavailable, Response{Error: "timeout waiting for request to finish"})
+	}
+}
+
+func (s *Server) subscribe() chan Event {
+	events := make(chan Event, subscriberQueue)
+	s.mutex.Lock()
+	s.subscribers[events] = struct{}{}
+	s.mutex.Unlock()
+	return events
+}
+
+func (s *Server) unsubscribe(events chan Event) {
+	s.mutex.Lock()
+	delete(s.subscribers, events)
+	s.mutex.Unlock()
+}
+
+// Execute runs the request function and converts the result into a Response.
+func (r Request) Execute(a api.API) Response {
+	result, err := r.Run(a)
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+	return Response{Result: result}
+}
+
+func render(w http.ResponseWriter, code int, resp Response) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
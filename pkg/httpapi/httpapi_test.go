@@ -0,0 +1,102 @@
+package httpapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/pkg/httpapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+const token = "secret"
+
+func TestHTTPAPI(t *testing.T) {
+	a := &api.MockAPI{}
+	a.On("Exec", "pause").Return(nil)
+	a.On("Exec", "foo").Return(fmt.Errorf("not a command: foo"))
+
+	server := httpapi.New(token)
+	ts := httptest.NewServer(server.Router())
+	defer ts.Close()
+
+	// Answer requests the same way the main loop would.
+	go func() {
+		for req := range server.Requests() {
+			req.Reply <- req.Execute(a)
+		}
+	}()
+
+	exec := func(command, tok string) *http.Response {
+		body, _ := json.Marshal(httpapi.ExecRequest{Command: command})
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/exec", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tok)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("requests without a valid token are rejected", func(t *testing.T) {
+		resp := exec("pause", "wrong")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		resp, err := http.Get(ts.URL + "/api/state")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("commands are run", func(t *testing.T) {
+		resp := exec("pause", token)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		a.AssertCalled(t, "Exec", "pause")
+	})
+
+	t.Run("command errors are returned to the client", func(t *testing.T) {
+		resp := exec("foo", token)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		result := httpapi.Response{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, "not a command: foo", result.Error)
+	})
+
+	t.Run("events are streamed to websocket clients", func(t *testing.T) {
+		url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/api/events?token=" + token
+		ws, err := websocket.Dial(url, "", ts.URL)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		// The subscription is registered asynchronously; keep sending until the client receives it.
+		received := make(chan httpapi.Event)
+		go func() {
+			ev := httpapi.Event{}
+			if websocket.JSON.Receive(ws, &ev) == nil {
+				received <- ev
+			}
+		}()
+
+		for {
+			server.Broadcast(httpapi.EventPlayer, map[string]string{"state": "play"})
+			select {
+			case ev := <-received:
+				assert.Equal(t, httpapi.EventPlayer, ev.Type)
+				assert.Equal(t, map[string]interface{}{"state": "play"}, ev.Data)
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"github.com/ambientsound/visp/list"
+)
+
+// ListInfo describes a list without its contents.
+type ListInfo struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Len     int      `json:"len"`
+	Cursor  int      `json:"cursor"`
+	Columns []string `json:"columns"`
+}
+
+// ListSummary describes a list along with all its rows.
+type ListSummary struct {
+	ListInfo
+	Rows []RowSummary `json:"rows"`
+}
+
+// RowSummary describes a single row.
+type RowSummary struct {
+	ID     string            `json:"id"`
+	Kind   list.DataType     `json:"kind"`
+	URI    string            `json:"uri,omitempty"`
+	Fields map[string]string `json:"fields"`
+}
+
+func NewListInfo(lst list.List) ListInfo {
+	return ListInfo{
+		ID:      lst.ID(),
+		Name:    lst.Name(),
+		Len:     lst.Len(),
+		Cursor:  lst.Cursor(),
+		Columns: lst.VisibleColumns(),
+	}
+}
+
+func NewListSummary(lst list.List) ListSummary {
+	rows := lst.All()
+	summary := ListSummary{
+		ListInfo: NewListInfo(lst),
+		Rows:     make([]RowSummary, len(rows)),
+	}
+	for i, row := range rows {
+		summary.Rows[i] = RowSummary{
+			ID:     row.ID(),
+			Kind:   row.Kind(),
+			URI:    string(row.URI()),
+			Fields: row.Fields(),
+		}
+	}
+	return summary
+}
@@ -91,7 +91,7 @@ func indexMapping() mapping.IndexMapping {
 // A persistent filesystem-backed index is tried first.
 // If this index cannot be opened, we use an in-memory index instead.
 func New() (Index, error) {
-	path := filepath.Join(xdg.CacheDirectory(), "visp", "library.idx")
+	path := filepath.Join(xdg.CacheDirectory(), "library.idx")
 
 	idx, err := bleve.Open(path)
 	if err != nil {
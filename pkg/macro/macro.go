@@ -0,0 +1,193 @@
+// Package macro records commands into named registers, and plays them back.
+package macro
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"unicode"
+)
+
+// LastRegister refers to the register that was most recently played back.
+const LastRegister = "@"
+
+// Entry is a single recorded command.
+type Entry struct {
+	Command string `json:"command"`
+	Count   int    `json:"count,omitempty"`
+}
+
+// Registers holds recorded macros, and the state of recording and playback.
+type Registers struct {
+	path      string
+	registers map[string][]Entry
+	recording string
+	playing   map[string]bool
+	last      string
+	await     func(register string) error
+}
+
+// New returns an empty set of registers, persisted to the given file path.
+// If path is empty, registers are kept in memory only.
+func New(path string) *Registers {
+	return &Registers{
+		path:      path,
+		registers: make(map[string][]Entry),
+		playing:   make(map[string]bool),
+	}
+}
+
+// ValidRegister returns an error if name is not a single letter or digit.
+func ValidRegister(name string) error {
+	r := []rune(name)
+	if len(r) != 1 || !(unicode.IsLetter(r[0]) || unicode.IsDigit(r[0])) {
+		return fmt.Errorf("invalid register '%s'; must be a letter or digit", name)
+	}
+	return nil
+}
+
+// Load reads registers from disk, replacing any registers in memory.
+func (r *Registers) Load() error {
+	if len(r.path) == 0 {
+		return nil
+	}
+	data, err := ioutil.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	registers := make(map[string][]Entry)
+	err = json.Unmarshal(data, &registers)
+	if err != nil {
+		return fmt.Errorf("%s: %w", r.path, err)
+	}
+	r.registers = registers
+	return nil
+}
+
+// Save writes all registers to disk.
+func (r *Registers) Save() error {
+	if len(r.path) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(r.registers, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(r.path), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+// Names returns the names of all non-empty registers, sorted.
+func (r *Registers) Names() []string {
+	names := make([]string, 0, len(r.registers))
+	for name, entries := range r.registers {
+		if len(entries) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the commands recorded in a register.
+func (r *Registers) Get(name string) []Entry {
+	return r.registers[name]
+}
+
+// Record clears a register and starts recording commands into it.
+func (r *Registers) Record(name string) error {
+	if err := ValidRegister(name); err != nil {
+		return err
+	}
+	r.recording = name
+	r.registers[name] = make([]Entry, 0)
+	return nil
+}
+
+// Stop stops recording, and returns the register that was recorded into.
+func (r *Registers) Stop() string {
+	name := r.recording
+	r.recording = ""
+	return name
+}
+
+// Recording returns the register currently being recorded into,
+// or an empty string if not recording.
+func (r *Registers) Recording() string {
+	return r.recording
+}
+
+// Add appends a command to the register being recorded, if any.
+// Commands executed as part of macro playback are not recorded.
+func (r *Registers) Add(entry Entry) {
+	if len(r.recording) == 0 || len(r.playing) > 0 {
+		return
+	}
+	r.registers[r.recording] = append(r.registers[r.recording], entry)
+}
+
+// Play runs the commands in a register count times, stopping at the first error.
+// The register name LastRegister refers to the most recently played register.
+func (r *Registers) Play(name string, count int, exec func(Entry) error) error {
+	if name == LastRegister {
+		if len(r.last) == 0 {
+			return fmt.Errorf("no previously played register")
+		}
+		name = r.last
+	}
+	if err := ValidRegister(name); err != nil {
+		return err
+	}
+	if r.playing[name] {
+		return fmt.Errorf("register '%s' is already playing", name)
+	}
+
+	entries := r.registers[name]
+	if len(entries) == 0 {
+		return fmt.Errorf("register '%s' is empty", name)
+	}
+
+	r.last = name
+	r.playing[name] = true
+	defer delete(r.playing, name)
+
+	if count < 1 {
+		count = 1
+	}
+	for n := 0; n < count; n++ {
+		for _, entry := range entries {
+			err := exec(entry)
+			if err != nil {
+				return fmt.Errorf("register '%s': %s: %w", name, entry.Command, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Await makes the next key press choose a register name, which is passed to fn.
+func (r *Registers) Await(fn func(register string) error) {
+	r.await = fn
+}
+
+// Awaiting returns true if the next key press should choose a register name.
+func (r *Registers) Awaiting() bool {
+	return r.await != nil
+}
+
+// Key passes a register name to the function given to Await.
+func (r *Registers) Key(name string) error {
+	fn := r.await
+	r.await = nil
+	if fn == nil {
+		return nil
+	}
+	return fn(name)
+}
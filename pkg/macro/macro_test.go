@@ -0,0 +1,80 @@
+package macro_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ambientsound/visp/pkg/macro"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndPlay(t *testing.T) {
+	registers := macro.New("")
+
+	// Commands are only recorded while recording.
+	registers.Add(macro.Entry{Command: "ignored"})
+	assert.NoError(t, registers.Record("a"))
+	assert.Equal(t, "a", registers.Recording())
+	registers.Add(macro.Entry{Command: "cursor down"})
+	registers.Add(macro.Entry{Command: "cut", Count: 2})
+	assert.Equal(t, "a", registers.Stop())
+	registers.Add(macro.Entry{Command: "ignored"})
+
+	assert.Equal(t, []string{"a"}, registers.Names())
+
+	played := make([]string, 0)
+	exec := func(entry macro.Entry) error {
+		played = append(played, fmt.Sprintf("%d%s", entry.Count, entry.Command))
+		return nil
+	}
+
+	assert.NoError(t, registers.Play("a", 2, exec))
+	assert.Equal(t, []string{"0cursor down", "2cut", "0cursor down", "2cut"}, played)
+
+	// The last played register can be played again.
+	played = played[:0]
+	assert.NoError(t, registers.Play(macro.LastRegister, 0, exec))
+	assert.Equal(t, []string{"0cursor down", "2cut"}, played)
+
+	assert.Error(t, registers.Play("b", 1, exec))
+	assert.Error(t, registers.Record("ab"))
+}
+
+func TestPlayRecursion(t *testing.T) {
+	registers := macro.New("")
+	_ = registers.Record("a")
+	registers.Add(macro.Entry{Command: "macro play a"})
+	registers.Stop()
+
+	err := registers.Play("a", 1, func(entry macro.Entry) error {
+		return registers.Play("a", 1, nil)
+	})
+	assert.Error(t, err)
+}
+
+func TestAwait(t *testing.T) {
+	registers := macro.New("")
+	assert.False(t, registers.Awaiting())
+
+	registers.Await(registers.Record)
+	assert.True(t, registers.Awaiting())
+	assert.NoError(t, registers.Key("z"))
+	assert.False(t, registers.Awaiting())
+	assert.Equal(t, "z", registers.Recording())
+}
+
+func TestPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visp", "macros.json")
+
+	registers := macro.New(path)
+	assert.Error(t, registers.Load())
+	_ = registers.Record("x")
+	registers.Add(macro.Entry{Command: "sort", Count: 2})
+	registers.Stop()
+	assert.NoError(t, registers.Save())
+
+	restored := macro.New(path)
+	assert.NoError(t, restored.Load())
+	assert.Equal(t, registers.Get("x"), restored.Get("x"))
+}
@@ -0,0 +1,455 @@
+// Package mpris exposes Visp on the D-Bus session bus using the MPRIS2
+// specification, so that desktop media keys and widgets can control playback.
+//
+// Method calls and property changes made by D-Bus clients are translated into
+// ordinary Visp commands, and sent on the Commands channel. The player state
+// is published by calling Update.
+//
+// See https://specifications.freedesktop.org/mpris-spec/latest/
+package mpris
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/player"
+	"github.com/ambientsound/visp/spotify/link"
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+	"github.com/zmb3/spotify/v2"
+)
+
+const (
+	BusName         = "org.mpris.MediaPlayer2.visp"
+	ObjectPath      = "/org/mpris/MediaPlayer2"
+	RootInterface   = "org.mpris.MediaPlayer2"
+	PlayerInterface = "org.mpris.MediaPlayer2.Player"
+
+	trackPathPrefix = "/org/ambientsound/visp/track/"
+	noTrack         = "/org/mpris/MediaPlayer2/TrackList/NoTrack"
+
+	// seekThreshold is how far the playback position may drift from its expected
+	// value before it is considered a seek.
+	seekThreshold = 2 * time.Second
+)
+
+// Playback status values.
+const (
+	StatusPlaying = "Playing"
+	StatusPaused  = "Paused"
+	StatusStopped = "Stopped"
+)
+
+// Loop status values.
+const (
+	LoopNone     = "None"
+	LoopTrack    = "Track"
+	LoopPlaylist = "Playlist"
+)
+
+// Server implements the MPRIS2 root and player interfaces.
+type Server struct {
+	conn     *dbus.Conn
+	props    *prop.Properties
+	commands chan string
+
+	// The state below is written by Update, and read by D-Bus method handlers,
+	// which run on another goroutine.
+	mu      sync.Mutex
+	trackID dbus.ObjectPath
+
+	// Playback position in microseconds at the last update, used to detect seeks.
+	position int64
+	playing  bool
+	updated  time.Time
+}
+
+// Connect exports the MPRIS2 interfaces on the session bus.
+func Connect() (*Server, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, err
+	}
+	srv, err := New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return srv, nil
+}
+
+// New exports the MPRIS2 interfaces on an existing bus connection, and
+// requests the well-known bus name. If the name is taken by another
+// instance, a unique name containing the process ID is used instead.
+func New(conn *dbus.Conn) (*Server, error) {
+	srv := &Server{
+		conn:     conn,
+		commands: make(chan string, 16),
+		trackID:  noTrack,
+	}
+
+	err := conn.Export(&root{srv}, ObjectPath, RootInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	// Seek can't be used as a Go method name without upsetting go vet,
+	// so it is mapped from another name.
+	err = conn.ExportWithMap(&mprisPlayer{srv}, playerMethodNames, ObjectPath, PlayerInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	srv.props, err = prop.Export(conn, ObjectPath, srv.properties())
+	if err != nil {
+		return nil, err
+	}
+
+	node := &introspect.Node{
+		Name: ObjectPath,
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			prop.IntrospectData,
+			{
+				Name:       RootInterface,
+				Methods:    introspect.Methods(&root{}),
+				Properties: srv.props.Introspection(RootInterface),
+			},
+			{
+				Name:       PlayerInterface,
+				Methods:    playerMethods(),
+				Properties: srv.props.Introspection(PlayerInterface),
+				Signals: []introspect.Signal{
+					{
+						Name: "Seeked",
+						Args: []introspect.Arg{{Name: "Position", Type: "x"}},
+					},
+				},
+			},
+		},
+	}
+
+	err = conn.Export(introspect.NewIntrospectable(node), ObjectPath, "org.freedesktop.DBus.Introspectable")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{BusName, fmt.Sprintf("%s.instance%d", BusName, os.Getpid())} {
+		reply, err := conn.RequestName(name, dbus.NameFlagDoNotQueue)
+		if err != nil {
+			return nil, err
+		}
+		if reply == dbus.RequestNameReplyPrimaryOwner {
+			log.Debugf("Acquired D-Bus name %s", name)
+			return srv, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to acquire D-Bus name %s", BusName)
+}
+
+// Commands returns a channel sending commands requested by D-Bus clients.
+func (s *Server) Commands() <-chan string {
+	return s.commands
+}
+
+// Close disconnects from the bus.
+func (s *Server) Close() error {
+	return s.conn.Close()
+}
+
+// Update publishes the player state. PropertiesChanged signals are
+// emitted only for properties that have actually changed.
+//
+// The Seeked signal is emitted if the playback position of the same track
+// jumps away from where it is expected to be.
+func (s *Server) Update(state player.State) {
+	trackID := trackPath(state.Item)
+	position := int64(state.Progress) * 1000
+	now := time.Now()
+
+	s.mu.Lock()
+	seeked := trackID == s.trackID && trackID != noTrack && s.seeked(position, now)
+	s.trackID = trackID
+	s.position = position
+	s.playing = state.State() == player.StatePlay
+	s.updated = now
+	s.mu.Unlock()
+
+	if seeked {
+		err := s.conn.Emit(ObjectPath, PlayerInterface+".Seeked", position)
+		if err != nil {
+			log.Errorf("MPRIS: emit Seeked: %s", err)
+		}
+	}
+
+	s.set(PlayerInterface, "PlaybackStatus", playbackStatus(state))
+	s.set(PlayerInterface, "LoopStatus", loopStatus(state.RepeatState))
+	s.set(PlayerInterface, "Shuffle", state.ShuffleState)
+	s.set(PlayerInterface, "Volume", float64(state.Device.Volume)/100)
+	s.set(PlayerInterface, "Metadata", metadata(state.Item))
+	s.set(PlayerInterface, "Position", int64(state.Progress)*1000)
+}
+
+// seeked returns true if position, in microseconds, is too far from the position expected at the given time.
+// The caller must hold s.mu.
+func (s *Server) seeked(position int64, now time.Time) bool {
+	expected := s.position
+	if s.playing {
+		expected += now.Sub(s.updated).Microseconds()
+	}
+	return math.Abs(float64(position-expected)) > float64(seekThreshold.Microseconds())
+}
+
+func (s *Server) set(iface, property string, value interface{}) {
+	if reflect.DeepEqual(s.props.GetMust(iface, property), value) {
+		return
+	}
+	s.props.SetMust(iface, property, value)
+}
+
+// send queues a command for execution.
+func (s *Server) send(format string, args ...interface{}) {
+	command := fmt.Sprintf(format, args...)
+	log.Debugf("MPRIS: %s", command)
+	s.commands <- command
+}
+
+func (s *Server) properties() prop.Map {
+	return prop.Map{
+		RootInterface: {
+			"CanQuit":             {Value: true},
+			"CanRaise":            {Value: false},
+			"HasTrackList":        {Value: false},
+			"Identity":            {Value: "Visp"},
+			"SupportedUriSchemes": {Value: []string{"spotify"}},
+			"SupportedMimeTypes":  {Value: []string{}},
+		},
+		PlayerInterface: {
+			"PlaybackStatus": {Value: StatusStopped, Emit: prop.EmitTrue},
+			"LoopStatus":     {Value: LoopNone, Emit: prop.EmitTrue, Writable: true, Callback: s.setLoopStatus},
+			"Rate":           {Value: 1.0, Emit: prop.EmitTrue},
+			"Shuffle":        {Value: false, Emit: prop.EmitTrue, Writable: true, Callback: s.setShuffle},
+			"Metadata":       {Value: metadata(nil), Emit: prop.EmitTrue},
+			"Volume":         {Value: 0.0, Emit: prop.EmitTrue, Writable: true, Callback: s.setVolume},
+			"Position":       {Value: int64(0), Emit: prop.EmitFalse},
+			"MinimumRate":    {Value: 1.0, Emit: prop.EmitTrue},
+			"MaximumRate":    {Value: 1.0, Emit: prop.EmitTrue},
+			"CanGoNext":      {Value: true, Emit: prop.EmitTrue},
+			"CanGoPrevious":  {Value: true, Emit: prop.EmitTrue},
+			"CanPlay":        {Value: true, Emit: prop.EmitTrue},
+			"CanPause":       {Value: true, Emit: prop.EmitTrue},
+			"CanSeek":        {Value: true, Emit: prop.EmitTrue},
+			"CanControl":     {Value: true, Emit: prop.EmitFalse},
+		},
+	}
+}
+
+func (s *Server) setLoopStatus(c *prop.Change) *dbus.Error {
+	switch c.Value {
+	case LoopNone:
+		s.send("repeat off")
+	case LoopTrack:
+		s.send("repeat track")
+	case LoopPlaylist:
+		s.send("repeat context")
+	default:
+		return prop.ErrInvalidArg
+	}
+	return nil
+}
+
+func (s *Server) setShuffle(c *prop.Change) *dbus.Error {
+	shuffle, ok := c.Value.(bool)
+	if !ok {
+		return prop.ErrInvalidArg
+	}
+	if shuffle {
+		s.send("shuffle on")
+	} else {
+		s.send("shuffle off")
+	}
+	return nil
+}
+
+func (s *Server) setVolume(c *prop.Change) *dbus.Error {
+	volume, ok := c.Value.(float64)
+	if !ok {
+		return prop.ErrInvalidArg
+	}
+	volume = math.Max(0, math.Min(1, volume))
+	s.send("volume %d", int(math.Round(volume*100)))
+	return nil
+}
+
+// root implements the org.mpris.MediaPlayer2 interface.
+type root struct {
+	srv *Server
+}
+
+// Raise is a no-op, because a terminal program can't raise its own window.
+func (r *root) Raise() *dbus.Error {
+	return nil
+}
+
+func (r *root) Quit() *dbus.Error {
+	r.srv.send("quit")
+	return nil
+}
+
+// mprisPlayer implements the org.mpris.MediaPlayer2.Player interface.
+type mprisPlayer struct {
+	srv *Server
+}
+
+// playerMethodNames maps Go method names to D-Bus method names.
+var playerMethodNames = map[string]string{
+	"SeekRelative": "Seek",
+}
+
+// playerMethods returns introspection data for the player methods,
+// using their D-Bus names.
+func playerMethods() []introspect.Method {
+	methods := introspect.Methods(&mprisPlayer{})
+	for i := range methods {
+		if name, ok := playerMethodNames[methods[i].Name]; ok {
+			methods[i].Name = name
+		}
+	}
+	return methods
+}
+
+func (p *mprisPlayer) Next() *dbus.Error {
+	p.srv.send("next")
+	return nil
+}
+
+func (p *mprisPlayer) Previous() *dbus.Error {
+	p.srv.send("previous")
+	return nil
+}
+
+func (p *mprisPlayer) Pause() *dbus.Error {
+	if p.srv.props.GetMust(PlayerInterface, "PlaybackStatus") == StatusPlaying {
+		p.srv.send("pause")
+	}
+	return nil
+}
+
+func (p *mprisPlayer) PlayPause() *dbus.Error {
+	p.srv.send("pause")
+	return nil
+}
+
+func (p *mprisPlayer) Stop() *dbus.Error {
+	p.srv.send("stop")
+	return nil
+}
+
+func (p *mprisPlayer) Play() *dbus.Error {
+	if p.srv.props.GetMust(PlayerInterface, "PlaybackStatus") != StatusPlaying {
+		p.srv.send("play")
+	}
+	return nil
+}
+
+// SeekRelative implements Seek, and seeks relative to the current position.
+// The offset is given in microseconds.
+func (p *mprisPlayer) SeekRelative(offset int64) *dbus.Error {
+	seconds := offset / 1000000
+	if seconds != 0 {
+		p.srv.send("seek %+d", seconds)
+	}
+	return nil
+}
+
+// SetPosition seeks to an absolute position, given in microseconds.
+// The request is ignored if the track is no longer playing.
+func (p *mprisPlayer) SetPosition(trackID dbus.ObjectPath, position int64) *dbus.Error {
+	p.srv.mu.Lock()
+	current := p.srv.trackID
+	p.srv.mu.Unlock()
+
+	if trackID != current || position < 0 {
+		return nil
+	}
+	p.srv.send("seek %d", position/1000000)
+	return nil
+}
+
+// OpenUri opens the track, album, artist, playlist or show that a Spotify URI points to.
+func (p *mprisPlayer) OpenUri(uri string) *dbus.Error {
+	link, err := spotify_link.Parse(uri)
+	if err != nil {
+		return dbus.MakeFailedError(err)
+	}
+	p.srv.send("open %s", link.URI())
+	return nil
+}
+
+func playbackStatus(state player.State) string {
+	switch state.State() {
+	case player.StatePlay:
+		return StatusPlaying
+	case player.StatePause:
+		return StatusPaused
+	default:
+		return StatusStopped
+	}
+}
+
+func loopStatus(repeat string) string {
+	switch repeat {
+	case "track":
+		return LoopTrack
+	case "context":
+		return LoopPlaylist
+	default:
+		return LoopNone
+	}
+}
+
+func trackPath(track *spotify.FullTrack) dbus.ObjectPath {
+	if track == nil || len(track.ID) == 0 {
+		return noTrack
+	}
+	return dbus.ObjectPath(trackPathPrefix + track.ID.String())
+}
+
+func metadata(track *spotify.FullTrack) map[string]dbus.Variant {
+	meta := map[string]dbus.Variant{
+		"mpris:trackid": dbus.MakeVariant(trackPath(track)),
+	}
+	if track == nil {
+		return meta
+	}
+
+	meta["mpris:length"] = dbus.MakeVariant(int64(track.Duration) * 1000)
+	meta["xesam:title"] = dbus.MakeVariant(track.Name)
+	meta["xesam:album"] = dbus.MakeVariant(track.Album.Name)
+	meta["xesam:artist"] = dbus.MakeVariant(artistNames(track.Artists))
+	meta["xesam:albumArtist"] = dbus.MakeVariant(artistNames(track.Album.Artists))
+	meta["xesam:trackNumber"] = dbus.MakeVariant(int32(track.TrackNumber))
+	meta["xesam:discNumber"] = dbus.MakeVariant(int32(track.DiscNumber))
+	meta["xesam:url"] = dbus.MakeVariant(track.ExternalURLs["spotify"])
+
+	if len(track.Album.Images) > 0 {
+		meta["mpris:artUrl"] = dbus.MakeVariant(track.Album.Images[0].URL)
+	}
+
+	return meta
+}
+
+func artistNames(artists []spotify.SimpleArtist) []string {
+	names := make([]string, len(artists))
+	for i := range artists {
+		names[i] = artists[i].Name
+	}
+	return names
+}
@@ -0,0 +1,191 @@
+package mpris_test
+
+import (
+	"bufio"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ambientsound/visp/pkg/mpris"
+	"github.com/ambientsound/visp/player"
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+)
+
+// privateBus starts a dbus-daemon for the duration of the test,
+// and returns its address.
+func privateBus(t *testing.T) string {
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not found in PATH")
+	}
+
+	socket := filepath.Join(t.TempDir(), "bus")
+	cmd := exec.Command(daemon, "--session", "--nofork", "--print-address", "--address=unix:path="+socket)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+
+	return strings.TrimSpace(address)
+}
+
+func connect(t *testing.T, address string) *dbus.Conn {
+	conn, err := dbus.Connect(address)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
+
+func receive(t *testing.T, srv *mpris.Server) string {
+	select {
+	case command := <-srv.Commands():
+		return command
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for command")
+		return ""
+	}
+}
+
+func TestMpris(t *testing.T) {
+	address := privateBus(t)
+
+	srv, err := mpris.New(connect(t, address))
+	require.NoError(t, err)
+
+	client := connect(t, address)
+	obj := client.Object(mpris.BusName, mpris.ObjectPath)
+
+	t.Run("methods are mapped to commands", func(t *testing.T) {
+		methods := map[string]string{
+			"Next":      "next",
+			"Previous":  "previous",
+			"PlayPause": "pause",
+			"Stop":      "stop",
+			"Play":      "play",
+		}
+		for method, command := range methods {
+			call := obj.Call(mpris.PlayerInterface+"."+method, 0)
+			require.NoError(t, call.Err)
+			assert.Equal(t, command, receive(t, srv))
+		}
+	})
+
+	t.Run("seek converts microseconds to seconds", func(t *testing.T) {
+		call := obj.Call(mpris.PlayerInterface+".Seek", 0, int64(-5000000))
+		require.NoError(t, call.Err)
+		assert.Equal(t, "seek -5", receive(t, srv))
+	})
+
+	t.Run("spotify links are opened", func(t *testing.T) {
+		call := obj.Call(mpris.PlayerInterface+".OpenUri", 0, "https://open.spotify.com/album/1DFixLWuPkv3KT3TnV35m3?si=abc")
+		require.NoError(t, call.Err)
+		assert.Equal(t, "open spotify:album:1DFixLWuPkv3KT3TnV35m3", receive(t, srv))
+
+		call = obj.Call(mpris.PlayerInterface+".OpenUri", 0, "file:///tmp/song.mp3")
+		assert.Error(t, call.Err)
+	})
+
+	t.Run("setting volume runs the volume command", func(t *testing.T) {
+		err := obj.SetProperty(mpris.PlayerInterface+".Volume", dbus.MakeVariant(0.42))
+		require.NoError(t, err)
+		assert.Equal(t, "volume 42", receive(t, srv))
+	})
+
+	t.Run("player state is published", func(t *testing.T) {
+		state := player.NewState(spotify.PlayerState{
+			CurrentlyPlaying: spotify.CurrentlyPlaying{
+				Playing: true,
+				Item: &spotify.FullTrack{
+					SimpleTrack: spotify.SimpleTrack{
+						ID:       "4uLU6hMCjMI75M1A2tKUQC",
+						Name:     "Never Gonna Give You Up",
+						Artists:  []spotify.SimpleArtist{{Name: "Rick Astley"}},
+						Duration: 213000,
+					},
+				},
+			},
+		})
+		srv.Update(*state)
+
+		status, err := obj.GetProperty(mpris.PlayerInterface + ".PlaybackStatus")
+		require.NoError(t, err)
+		assert.Equal(t, mpris.StatusPlaying, status.Value())
+
+		variant, err := obj.GetProperty(mpris.PlayerInterface + ".Metadata")
+		require.NoError(t, err)
+		metadata := variant.Value().(map[string]dbus.Variant)
+		assert.Equal(t, "Never Gonna Give You Up", metadata["xesam:title"].Value())
+		assert.Equal(t, []string{"Rick Astley"}, metadata["xesam:artist"].Value())
+		assert.Equal(t, int64(213000000), metadata["mpris:length"].Value())
+	})
+
+	t.Run("rate is read-only", func(t *testing.T) {
+		err := obj.SetProperty(mpris.PlayerInterface+".Rate", dbus.MakeVariant(2.0))
+		assert.Error(t, err)
+	})
+
+	t.Run("seeked is emitted when the position jumps", func(t *testing.T) {
+		require.NoError(t, client.AddMatchSignal(dbus.WithMatchInterface(mpris.PlayerInterface), dbus.WithMatchMember("Seeked")))
+		signals := make(chan *dbus.Signal, 4)
+		client.Signal(signals)
+
+		track := &spotify.FullTrack{SimpleTrack: spotify.SimpleTrack{ID: "7GhIk7Il098yCjg4BQjzvb"}}
+		update := func(progress int) {
+			srv.Update(*player.NewState(spotify.PlayerState{
+				CurrentlyPlaying: spotify.CurrentlyPlaying{Playing: true, Progress: progress, Item: track},
+			}))
+		}
+
+		// Changing tracks and normal playback are not seeks.
+		update(10000)
+		update(10500)
+		update(95000)
+
+		select {
+		case signal := <-signals:
+			assert.Equal(t, []interface{}{int64(95000000)}, signal.Body)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for Seeked")
+		}
+		select {
+		case signal := <-signals:
+			t.Fatalf("unexpected signal %v", signal.Body)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("position can be set while the state is updated", func(t *testing.T) {
+		track := &spotify.FullTrack{SimpleTrack: spotify.SimpleTrack{ID: "3n3Ppam7vgaVa1iaRUc9Lp"}}
+		state := *player.NewState(spotify.PlayerState{
+			CurrentlyPlaying: spotify.CurrentlyPlaying{Playing: true, Item: track},
+		})
+		srv.Update(state)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 50; i++ {
+				srv.Update(state)
+			}
+		}()
+
+		path := dbus.ObjectPath("/org/ambientsound/visp/track/3n3Ppam7vgaVa1iaRUc9Lp")
+		call := obj.Call(mpris.PlayerInterface+".SetPosition", 0, path, int64(30000000))
+		require.NoError(t, call.Err)
+		assert.Equal(t, "seek 30", receive(t, srv))
+		<-done
+	})
+}
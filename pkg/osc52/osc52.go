@@ -0,0 +1,40 @@
+// Package osc52 copies text to the system clipboard of the terminal, using the OSC 52 escape sequence.
+//
+// The sequence is interpreted by the terminal emulator itself, so copying works
+// over SSH as well. Inside tmux or GNU screen, the sequence is wrapped in a
+// passthrough sequence, so that it reaches the outer terminal.
+package osc52
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+)
+
+// Output is where the escape sequences are written, and is replaced in tests.
+var Output io.Writer = os.Stdout
+
+// Sequence returns the escape sequence that copies text to the system clipboard.
+func Sequence(text string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+}
+
+// Wrap wraps an escape sequence for the terminal multiplexer visp is running in, if any.
+func Wrap(seq string) string {
+	switch {
+	case len(os.Getenv("TMUX")) > 0:
+		// Escape characters within the passthrough sequence must be doubled.
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		return "\x1bP" + seq + "\x1b\\"
+	default:
+		return seq
+	}
+}
+
+// Copy writes text to the system clipboard.
+func Copy(text string) error {
+	_, err := io.WriteString(Output, Wrap(Sequence(text)))
+	return err
+}
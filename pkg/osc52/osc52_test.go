@@ -0,0 +1,54 @@
+package osc52_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/ambientsound/visp/pkg/osc52"
+	"github.com/stretchr/testify/assert"
+)
+
+func setenv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestSequence(t *testing.T) {
+	assert.Equal(t, "\x1b]52;c;aGVsbG8=\x07", osc52.Sequence("hello"))
+}
+
+func TestWrap(t *testing.T) {
+	seq := osc52.Sequence("hello")
+
+	setenv(t, "TMUX", "")
+	setenv(t, "TERM", "xterm-256color")
+	assert.Equal(t, seq, osc52.Wrap(seq))
+
+	setenv(t, "TERM", "screen-256color")
+	assert.Equal(t, "\x1bP\x1b]52;c;aGVsbG8=\x07\x1b\\", osc52.Wrap(seq))
+
+	setenv(t, "TMUX", "/tmp/tmux-1000/default,1234,0")
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\x07\x1b\\", osc52.Wrap(seq))
+}
+
+func TestCopy(t *testing.T) {
+	setenv(t, "TMUX", "")
+	setenv(t, "TERM", "xterm")
+
+	buf := &bytes.Buffer{}
+	osc52.Output = buf
+	defer func() {
+		osc52.Output = os.Stdout
+	}()
+
+	assert.NoError(t, osc52.Copy("hello"))
+	assert.Equal(t, "\x1b]52;c;aGVsbG8=\x07", buf.String())
+}
@@ -0,0 +1,99 @@
+package script
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/parser"
+	"github.com/ambientsound/visp/utils"
+)
+
+// Command runs a verb defined in a script.
+type Command struct {
+	parser.Parser
+	api  api.API
+	verb *Verb
+	args []string
+
+	// partial is the argument under the cursor when tab completing.
+	partial string
+}
+
+var _ commands.Command = &Command{}
+
+// Constructor returns a function that creates commands for this verb.
+func (verb *Verb) Constructor() func(api.API) commands.Command {
+	return func(a api.API) commands.Command {
+		return &Command{
+			api:  a,
+			verb: verb,
+		}
+	}
+}
+
+// Description returns the help text of this verb.
+func (verb *Verb) Description() commands.Description {
+	return commands.Description{
+		Summary: verb.Help,
+		Usage:   []string{verb.Name + " [<argument> [...]]"},
+	}
+}
+
+// Parse implements commands.Command.
+// Arguments are separated by whitespace, and may be quoted.
+func (cmd *Command) Parse() error {
+	cmd.args = make([]string, 0)
+	arg := ""
+
+	for {
+		tok, lit := cmd.Scan()
+
+		switch tok {
+		case lexer.TokenWhitespace:
+			if len(arg) > 0 {
+				cmd.args = append(cmd.args, arg)
+			}
+			arg = ""
+		case lexer.TokenEnd, lexer.TokenComment:
+			cmd.partial = arg
+			if len(arg) > 0 {
+				cmd.args = append(cmd.args, arg)
+			}
+			return nil
+		default:
+			arg += lit
+		}
+	}
+}
+
+// Exec implements commands.Command.
+func (cmd *Command) Exec() error {
+	return cmd.verb.Run(cmd.api, cmd.args)
+}
+
+// TabComplete implements commands.Command.
+func (cmd *Command) TabComplete() []string {
+	preceding := cmd.args
+	if len(cmd.partial) > 0 {
+		preceding = cmd.args[:len(cmd.args)-1]
+	}
+
+	candidates, err := cmd.verb.Complete(cmd.api, preceding)
+	if err != nil {
+		log.Errorf("Tab complete %s: %s", cmd.verb.Name, err)
+		return []string{}
+	}
+
+	candidates = utils.TokenFilter(cmd.partial, candidates)
+	for i := range candidates {
+		if strings.ContainsAny(candidates[i], " \t\"#;|$") {
+			candidates[i] = strconv.Quote(candidates[i])
+		}
+	}
+
+	return candidates
+}
@@ -0,0 +1,166 @@
+package script
+
+import (
+	"fmt"
+
+	"github.com/ambientsound/visp/api"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// module contains the functions that scripts can use to inspect and control Visp.
+var module = &starlarkstruct.Module{
+	Name: "visp",
+	Members: starlark.StringDict{
+		"exec":   starlark.NewBuiltin("exec", execFn),
+		"list":   starlark.NewBuiltin("list", listFn),
+		"player": starlark.NewBuiltin("player", playerFn),
+		"rows":   starlark.NewBuiltin("rows", rowsFn),
+		"select": starlark.NewBuiltin("select", selectFn),
+	},
+}
+
+// exec(command) runs a Visp command.
+func execFn(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var command string
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "command", &command)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := writableAPI(thread, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return starlark.None, a.Exec(command)
+}
+
+// list() returns information about the active list.
+func listFn(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	err := starlark.UnpackArgs(b.Name(), args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+
+	lst := threadAPI(thread).List()
+	if lst == nil {
+		return starlark.None, nil
+	}
+
+	selection := make([]starlark.Value, 0)
+	for _, i := range lst.SelectionIndices() {
+		selection = append(selection, starlark.MakeInt(i))
+	}
+
+	return toDict(map[string]starlark.Value{
+		"id":        starlark.String(lst.ID()),
+		"name":      starlark.String(lst.Name()),
+		"len":       starlark.MakeInt(lst.Len()),
+		"cursor":    starlark.MakeInt(lst.Cursor()),
+		"columns":   stringList(lst.VisibleColumns()),
+		"selection": starlark.NewList(selection),
+	}), nil
+}
+
+// player() returns the player state.
+func playerFn(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	err := starlark.UnpackArgs(b.Name(), args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := threadAPI(thread).PlayerStatus().Summary()
+
+	return toDict(map[string]starlark.Value{
+		"state":    starlark.String(summary.State),
+		"progress": starlark.MakeInt(summary.Progress),
+		"duration": starlark.MakeInt(summary.Duration),
+		"volume":   starlark.MakeInt(summary.Volume),
+		"shuffle":  starlark.Bool(summary.Shuffle),
+		"repeat":   starlark.String(summary.Repeat),
+		"liked":    starlark.Bool(summary.Liked),
+		"device":   starlark.String(summary.Device),
+		"track":    stringDict(summary.Track),
+	}), nil
+}
+
+// rows(selected=False) returns the rows of the active list, or only the selected rows.
+func rowsFn(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var selected bool
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "selected?", &selected)
+	if err != nil {
+		return nil, err
+	}
+
+	lst := threadAPI(thread).List()
+	if lst == nil {
+		return starlark.NewList(nil), nil
+	}
+
+	rows := make([]starlark.Value, 0, lst.Len())
+	for i, row := range lst.All() {
+		if selected && !lst.Selected(i) {
+			continue
+		}
+		rows = append(rows, toDict(map[string]starlark.Value{
+			"index":  starlark.MakeInt(i),
+			"id":     starlark.String(row.ID()),
+			"kind":   starlark.String(row.Kind()),
+			"uri":    starlark.String(row.URI()),
+			"fields": stringDict(row.Fields()),
+		}))
+	}
+
+	return starlark.NewList(rows), nil
+}
+
+// select(indices, selected=True) selects or deselects rows in the active list.
+func selectFn(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var indices starlark.Iterable
+	selected := true
+	err := starlark.UnpackArgs(b.Name(), args, kwargs, "indices", &indices, "selected?", &selected)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := writableAPI(thread, b)
+	if err != nil {
+		return nil, err
+	}
+
+	lst := a.List()
+	if lst == nil {
+		return nil, fmt.Errorf("%s: no active list", b.Name())
+	}
+
+	iter := indices.Iterate()
+	defer iter.Done()
+
+	var value starlark.Value
+	for iter.Next(&value) {
+		i, err := starlark.AsInt32(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+		if !lst.InRange(i) {
+			return nil, fmt.Errorf("%s: index %d out of range", b.Name(), i)
+		}
+		lst.SetSelected(i, selected)
+	}
+
+	return starlark.None, nil
+}
+
+func threadAPI(thread *starlark.Thread) api.API {
+	return thread.Local(apiLocal).(api.API)
+}
+
+// writableAPI returns the API, unless the function is running in a context
+// where it must not have any side effects, such as tab completion.
+func writableAPI(thread *starlark.Thread, b *starlark.Builtin) (api.API, error) {
+	if readonly, _ := thread.Local(readonlyLocal).(bool); readonly {
+		return nil, fmt.Errorf("%s: not allowed during tab completion", b.Name())
+	}
+	return threadAPI(thread), nil
+}
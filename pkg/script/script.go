@@ -0,0 +1,134 @@
+// Package script lets users define their own commands in Starlark,
+// a small, sandboxed dialect of Python.
+//
+// Scripts register commands with the `command` builtin:
+//
+//	def dedupe(args):
+//	    ...
+//
+//	command("dedupe", dedupe, complete=["artist", "title"])
+//
+// Commands are run with a list of string arguments, and can inspect and
+// control Visp through the functions in the `visp` module.
+// Scripts cannot access the file system or the network.
+package script
+
+import (
+	"fmt"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/log"
+	"go.starlark.net/starlark"
+)
+
+const (
+	// Extension is the file name extension of script files.
+	Extension = ".star"
+
+	// maxExecutionSteps stops runaway scripts before they hang the user interface.
+	maxExecutionSteps = 10000000
+
+	apiLocal      = "api"
+	readonlyLocal = "readonly"
+)
+
+// Verb is a command defined in a script.
+type Verb struct {
+	Name     string
+	Help     string
+	fn       starlark.Callable
+	complete starlark.Value
+}
+
+// Load runs a script file, and returns the commands it defines.
+func Load(a api.API, path string) ([]*Verb, error) {
+	verbs := make([]*Verb, 0)
+
+	register := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name, help string
+		var fn starlark.Callable
+		var complete starlark.Value = starlark.None
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "fn", &fn, "complete?", &complete, "help?", &help)
+		if err != nil {
+			return nil, err
+		}
+
+		switch complete.(type) {
+		case starlark.NoneType, *starlark.List, starlark.Tuple, starlark.Callable:
+		default:
+			return nil, fmt.Errorf("%s: complete must be a list of strings or a function, not %s", b.Name(), complete.Type())
+		}
+
+		verbs = append(verbs, &Verb{
+			Name:     name,
+			Help:     help,
+			fn:       fn,
+			complete: complete,
+		})
+
+		return starlark.None, nil
+	}
+
+	predeclared := starlark.StringDict{
+		"command": starlark.NewBuiltin("command", register),
+		"visp":    module,
+	}
+
+	thread := newThread(a, path, false)
+	_, err := starlark.ExecFile(thread, path, nil, predeclared)
+	if err != nil {
+		return nil, describe(err)
+	}
+
+	return verbs, nil
+}
+
+// Run executes the command with the given arguments.
+func (verb *Verb) Run(a api.API, args []string) error {
+	thread := newThread(a, verb.Name, false)
+	_, err := starlark.Call(thread, verb.fn, starlark.Tuple{stringList(args)}, nil)
+	return describe(err)
+}
+
+// Complete returns tab completion candidates for the next argument, given the preceding arguments.
+// Completion functions cannot change any state.
+func (verb *Verb) Complete(a api.API, args []string) ([]string, error) {
+	candidates := verb.complete
+
+	if fn, ok := verb.complete.(starlark.Callable); ok {
+		var err error
+		thread := newThread(a, verb.Name, true)
+		candidates, err = starlark.Call(thread, fn, starlark.Tuple{stringList(args)}, nil)
+		if err != nil {
+			return nil, describe(err)
+		}
+	}
+
+	if candidates == starlark.None {
+		return []string{}, nil
+	}
+
+	return toStrings(candidates)
+}
+
+func newThread(a api.API, name string, readonly bool) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Infof("%s", msg)
+		},
+	}
+	thread.SetLocal(apiLocal, a)
+	thread.SetLocal(readonlyLocal, readonly)
+	thread.SetMaxExecutionSteps(maxExecutionSteps)
+	return thread
+}
+
+// describe includes the Starlark backtrace in script errors.
+func describe(err error) error {
+	if evalErr, ok := err.(*starlark.EvalError); ok {
+		return fmt.Errorf("%s", evalErr.Backtrace())
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package script_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/input/lexer"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/pkg/script"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const source = `
+def print_selected(args):
+    for row in visp.rows(selected=True):
+        visp.exec("print " + row["fields"]["title"])
+
+def select_artist(args):
+    visp.select([row["index"] for row in visp.rows() if row["fields"]["artist"] == args[0]])
+
+def complete_artists(args):
+    if len(args) > 0:
+        return []
+    return sorted({row["fields"]["artist"]: True for row in visp.rows()}.keys())
+
+def forbidden(args):
+    visp.exec("quit")
+    return []
+
+command("print-selected", print_selected, help="Print titles of selected tracks")
+command("select-artist", select_artist, complete=complete_artists)
+command("forbidden", print_selected, complete=forbidden)
+command("static", print_selected, complete=["foo", "foo bar", "baz"])
+`
+
+func testList() list.List {
+	lst := list.New()
+	lst.Add(list.NewRow("1", list.DataTypeTrack, map[string]string{"artist": "Madrugada", "title": "Black Mambo"}))
+	lst.Add(list.NewRow("2", list.DataTypeTrack, map[string]string{"artist": "Spoonbill", "title": "Tesla"}))
+	lst.Add(list.NewRow("3", list.DataTypeTrack, map[string]string{"artist": "Madrugada", "title": "Majesty"}))
+	return lst
+}
+
+func load(t *testing.T, a api.API) map[string]*script.Verb {
+	path := filepath.Join(t.TempDir(), "test"+script.Extension)
+	require.NoError(t, os.WriteFile(path, []byte(source), 0644))
+
+	verbs, err := script.Load(a, path)
+	require.NoError(t, err)
+
+	m := make(map[string]*script.Verb)
+	for _, verb := range verbs {
+		m[verb.Name] = verb
+	}
+	return m
+}
+
+func TestScript(t *testing.T) {
+	a := &api.MockAPI{}
+	lst := testList()
+	a.On("List").Return(lst)
+	a.On("Exec", "print Black Mambo").Return(nil)
+
+	verbs := load(t, a)
+	require.Len(t, verbs, 4)
+	assert.Equal(t, "Print titles of selected tracks", verbs["print-selected"].Help)
+
+	t.Run("scripts can select rows", func(t *testing.T) {
+		err := verbs["select-artist"].Run(a, []string{"Madrugada"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 2}, lst.SelectionIndices())
+		lst.ClearSelection()
+	})
+
+	t.Run("scripts can run commands", func(t *testing.T) {
+		lst.SetSelected(0, true)
+		err := verbs["print-selected"].Run(a, []string{})
+		require.NoError(t, err)
+		a.AssertCalled(t, "Exec", "print Black Mambo")
+		lst.ClearSelection()
+	})
+
+	t.Run("tab completion functions", func(t *testing.T) {
+		items, err := verbs["select-artist"].Complete(a, []string{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Madrugada", "Spoonbill"}, items)
+	})
+
+	t.Run("tab completion cannot change state", func(t *testing.T) {
+		_, err := verbs["forbidden"].Complete(a, []string{})
+		assert.Error(t, err)
+		a.AssertNotCalled(t, "Exec", "quit")
+	})
+
+	t.Run("command arguments are tab completed", func(t *testing.T) {
+		cmd := verbs["static"].Constructor()(a)
+		cmd.SetScanner(lexer.NewScanner(strings.NewReader(`"foo`)))
+		require.NoError(t, cmd.Parse())
+		assert.Equal(t, []string{"foo", `"foo bar"`}, cmd.TabComplete())
+	})
+}
+
+func TestLoadErrors(t *testing.T) {
+	a := &api.MockAPI{}
+	path := filepath.Join(t.TempDir(), "broken"+script.Extension)
+
+	require.NoError(t, os.WriteFile(path, []byte(`command("foo", "not a function")`), 0644))
+	_, err := script.Load(a, path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`load("other.star", "x")`), 0644))
+	_, err = script.Load(a, path)
+	assert.Error(t, err)
+}
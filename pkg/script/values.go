@@ -0,0 +1,62 @@
+package script
+
+import (
+	"fmt"
+	"sort"
+
+	"go.starlark.net/starlark"
+)
+
+func stringList(s []string) *starlark.List {
+	values := make([]starlark.Value, len(s))
+	for i := range s {
+		values[i] = starlark.String(s[i])
+	}
+	return starlark.NewList(values)
+}
+
+func stringDict(m map[string]string) *starlark.Dict {
+	values := make(map[string]starlark.Value, len(m))
+	for k, v := range m {
+		values[k] = starlark.String(v)
+	}
+	return toDict(values)
+}
+
+// toDict converts a map into a dictionary with sorted keys.
+func toDict(m map[string]starlark.Value) *starlark.Dict {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	dict := starlark.NewDict(len(m))
+	for _, k := range keys {
+		_ = dict.SetKey(starlark.String(k), m[k])
+	}
+	return dict
+}
+
+// toStrings converts a list of strings into a string slice.
+func toStrings(value starlark.Value) ([]string, error) {
+	iterable, ok := value.(starlark.Iterable)
+	if !ok {
+		return nil, fmt.Errorf("expected list of strings, got %s", value.Type())
+	}
+
+	s := make([]string, 0)
+	iter := iterable.Iterate()
+	defer iter.Done()
+
+	var item starlark.Value
+	for iter.Next(&item) {
+		str, ok := starlark.AsString(item)
+		if !ok {
+			return nil, fmt.Errorf("expected list of strings, got %s in list", item.Type())
+		}
+		s = append(s, str)
+	}
+
+	return s, nil
+}
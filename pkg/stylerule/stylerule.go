@@ -0,0 +1,180 @@
+// Package stylerule styles table rows according to the values of their fields.
+package stylerule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Comparison operators, longest first so that `<=` is not read as `<`.
+var operators = []string{"!=", "<=", ">=", "=", "<", ">", "~"}
+
+// Rule applies a style to rows whose fields match a predicate.
+type Rule struct {
+	Style string
+	Field string
+	Op    string
+	Value string
+
+	// Negate is set for predicates on the form `!field`.
+	Negate bool
+}
+
+// Parse creates a rule from a style name and a predicate. Predicates are either on the form
+// `field` or `!field`, which test whether a field is set, or `field<op>value`, where op is one of
+// `=`, `!=`, `<`, `<=`, `>`, `>=`, or `~` for a case-insensitive substring match.
+func Parse(style, predicate string) (Rule, error) {
+	rule := Rule{Style: style}
+
+	if len(style) == 0 {
+		return rule, fmt.Errorf("style rule has no style name")
+	}
+
+	rule.Field, rule.Op, rule.Value = split(predicate)
+
+	if len(rule.Op) == 0 {
+		rule.Field = predicate
+		if strings.HasPrefix(predicate, "!") {
+			rule.Field = predicate[1:]
+			rule.Negate = true
+		}
+	}
+
+	if len(rule.Field) == 0 {
+		return rule, fmt.Errorf("invalid predicate '%s'; expected a field name", predicate)
+	}
+
+	return rule, nil
+}
+
+// split finds the first operator in a predicate, and returns the field name, operator and value.
+// The operator is empty if there is none.
+func split(predicate string) (field, op, value string) {
+	for i := range predicate {
+		for _, op := range operators {
+			if strings.HasPrefix(predicate[i:], op) {
+				return predicate[:i], op, predicate[i+len(op):]
+			}
+		}
+	}
+	return predicate, "", ""
+}
+
+// Match returns true if the rule's predicate is true for the given fields.
+func (r Rule) Match(fields map[string]string) bool {
+	value := fields[r.Field]
+
+	switch r.Op {
+	case "":
+		return truthy(value) != r.Negate
+	case "=":
+		return value == r.Value
+	case "!=":
+		return value != r.Value
+	case "~":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(r.Value))
+	}
+
+	if len(value) == 0 {
+		return false
+	}
+
+	cmp := compare(value, r.Value)
+	switch r.Op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+
+	return false
+}
+
+// String returns the rule in the format accepted by the `stylerule` command.
+func (r Rule) String() string {
+	if r.Negate {
+		return r.Style + " !" + r.Field
+	}
+	if strings.ContainsAny(r.Value, " \t") {
+		return r.Style + " " + r.Field + r.Op + strconv.Quote(r.Value)
+	}
+	return r.Style + " " + r.Field + r.Op + r.Value
+}
+
+// truthy returns false for empty values and values such as `no` and `false`.
+func truthy(value string) bool {
+	switch strings.ToLower(value) {
+	case "", "0", "no", "false":
+		return false
+	}
+	return true
+}
+
+// compare compares two values as numbers if both are numeric, or as strings otherwise.
+func compare(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// Rules is an ordered set of style rules.
+type Rules struct {
+	rules []Rule
+}
+
+// New returns an empty set of rules.
+func New() *Rules {
+	return &Rules{
+		rules: make([]Rule, 0),
+	}
+}
+
+// Add appends a rule, so that it is evaluated after all existing rules.
+func (r *Rules) Add(rule Rule) {
+	r.rules = append(r.rules, rule)
+}
+
+// Clear removes all rules.
+func (r *Rules) Clear() {
+	r.rules = make([]Rule, 0)
+}
+
+// All returns all rules, in the order they are evaluated.
+func (r *Rules) All() []Rule {
+	return r.rules
+}
+
+// Uses returns true if any rule tests the given field.
+func (r *Rules) Uses(field string) bool {
+	for _, rule := range r.rules {
+		if rule.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Style returns the style of the first rule matching the given fields.
+// Returns false if no rule matches.
+func (r *Rules) Style(fields map[string]string) (string, bool) {
+	for _, rule := range r.rules {
+		if rule.Match(fields) {
+			return rule.Style, true
+		}
+	}
+	return "", false
+}
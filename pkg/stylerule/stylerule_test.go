@@ -0,0 +1,99 @@
+package stylerule_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/pkg/stylerule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	fields := map[string]string{
+		"artist":     "The Beatles",
+		"explicit":   "yes",
+		"playable":   "no",
+		"popularity": "0.15",
+		"year":       "1969",
+	}
+
+	tests := []struct {
+		predicate string
+		match     bool
+	}{
+		{"explicit", true},
+		{"!explicit", false},
+		{"playable", false},
+		{"!playable", true},
+		{"nonexist", false},
+		{"!nonexist", true},
+		{"artist=The Beatles", true},
+		{"artist=the beatles", false},
+		{"artist!=Queen", true},
+		{"artist~beat", true},
+		{"artist~queen", false},
+		{"popularity<0.20", true},
+		{"popularity<=0.15", true},
+		{"popularity>0.15", false},
+		{"popularity>=0.2", false},
+		{"year<1970", true},
+		{"year>01970", false},
+		{"nonexist<1", false},
+	}
+
+	for _, test := range tests {
+		rule, err := stylerule.Parse("style", test.predicate)
+		require.NoError(t, err, test.predicate)
+		assert.Equal(t, test.match, rule.Match(fields), test.predicate)
+	}
+}
+
+func TestParse(t *testing.T) {
+	rule, err := stylerule.Parse("dim", "popularity<=0.2")
+	require.NoError(t, err)
+	assert.Equal(t, "popularity", rule.Field)
+	assert.Equal(t, "<=", rule.Op)
+	assert.Equal(t, "0.2", rule.Value)
+	assert.Equal(t, "dim popularity<=0.2", rule.String())
+
+	rule, err = stylerule.Parse("red", "title!=foo bar")
+	require.NoError(t, err)
+	assert.Equal(t, "!=", rule.Op)
+	assert.Equal(t, `red title!="foo bar"`, rule.String())
+
+	_, err = stylerule.Parse("", "explicit")
+	assert.Error(t, err)
+
+	_, err = stylerule.Parse("red", "=foo")
+	assert.Error(t, err)
+
+	_, err = stylerule.Parse("red", "!")
+	assert.Error(t, err)
+}
+
+func TestRules(t *testing.T) {
+	rules := stylerule.New()
+	for _, r := range [][2]string{{"red", "explicit"}, {"dim", "popularity<0.5"}} {
+		rule, err := stylerule.Parse(r[0], r[1])
+		require.NoError(t, err)
+		rules.Add(rule)
+	}
+
+	// The first matching rule wins.
+	name, ok := rules.Style(map[string]string{"explicit": "yes", "popularity": "0.1"})
+	assert.True(t, ok)
+	assert.Equal(t, "red", name)
+
+	name, ok = rules.Style(map[string]string{"explicit": "no", "popularity": "0.1"})
+	assert.True(t, ok)
+	assert.Equal(t, "dim", name)
+
+	_, ok = rules.Style(map[string]string{"explicit": "no", "popularity": "0.9"})
+	assert.False(t, ok)
+
+	assert.True(t, rules.Uses("popularity"))
+	assert.False(t, rules.Uses("liked"))
+
+	rules.Clear()
+	assert.Len(t, rules.All(), 0)
+}
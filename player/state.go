@@ -16,8 +16,15 @@ type State struct {
 	CreateTime         time.Time
 	ProgressPercentage float64
 	TrackRow           list.Row
-	liked              *bool
-	updateTime         time.Time
+
+	// NextRow is the next track in the playback queue, or nil if unknown.
+	NextRow list.Row
+
+	// ContextName is the name of the playlist, album or artist that is playing.
+	ContextName string
+
+	liked      *bool
+	updateTime time.Time
 }
 
 func NewState(state spotify.PlayerState) *State {
@@ -0,0 +1,45 @@
+package player
+
+// Summary is a serializable representation of the player state,
+// meant for consumption by external programs.
+type Summary struct {
+	State    string            `json:"state"`
+	Progress int               `json:"progress"`
+	Duration int               `json:"duration"`
+	Volume   int               `json:"volume"`
+	Shuffle  bool              `json:"shuffle"`
+	Repeat   string            `json:"repeat"`
+	Liked    bool              `json:"liked"`
+	Device   string            `json:"device"`
+	Track    map[string]string `json:"track"`
+}
+
+// Summary returns a snapshot of the player state. Track fields are copied
+// from the track row, along with the track's ID and Spotify URI.
+func (p State) Summary() Summary {
+	track := make(map[string]string)
+	for k, v := range p.TrackRow.Fields() {
+		track[k] = v
+	}
+	if len(p.TrackRow.ID()) > 0 {
+		track["id"] = p.TrackRow.ID()
+		track["uri"] = string(p.TrackRow.URI())
+	}
+
+	summary := Summary{
+		State:    p.State(),
+		Progress: p.Progress,
+		Volume:   p.Device.Volume,
+		Shuffle:  p.ShuffleState,
+		Repeat:   p.RepeatState,
+		Liked:    p.Liked(),
+		Device:   p.Device.Name,
+		Track:    track,
+	}
+
+	if p.Item != nil {
+		summary.Duration = p.Item.Duration
+	}
+
+	return summary
+}
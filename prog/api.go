@@ -13,8 +13,12 @@ import (
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/multibar"
 	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/macro"
+	"github.com/ambientsound/visp/pkg/stylerule"
 	"github.com/ambientsound/visp/player"
 	"github.com/ambientsound/visp/spotify/library"
+	"github.com/ambientsound/visp/spotify/likes"
+	"github.com/ambientsound/visp/spotify/nowplaying"
 	"github.com/ambientsound/visp/spotify/proxyclient"
 	"github.com/ambientsound/visp/spotify/tracklist"
 	"github.com/ambientsound/visp/style"
@@ -55,10 +59,23 @@ func (v *Visp) Exec(command string) error {
 	return v.interpreter.Exec(command)
 }
 
+func (v *Visp) ExecCount(command string, count int) error {
+	log.Debugf("Run command: %s (count %d)", command, count)
+	return v.interpreter.ExecCount(command, count)
+}
+
 func (v *Visp) Library() *spotify_library.List {
 	return v.library
 }
 
+func (v *Visp) Likes() *spotify_likes.Cache {
+	return v.likes
+}
+
+func (v *Visp) NowPlaying() *spotify_nowplaying.List {
+	return v.nowPlaying
+}
+
 func (v *Visp) List() list.List {
 	return v.list
 }
@@ -73,6 +90,7 @@ func (v *Visp) Changed(change api.ChangeType, data interface{}) {
 		}
 		v.db.Cache(lst)
 		v.clipboards.Update(lst)
+		v.broadcastList(lst)
 		// TODO: playlists indexes
 
 	case api.ChangeOption:
@@ -103,10 +121,18 @@ func (v *Visp) Quit() {
 	v.quit <- new(interface{})
 }
 
+func (v *Visp) Repeat(count int) error {
+	return v.interpreter.Repeat(count)
+}
+
 func (v *Visp) Sequencer() *keys.Sequencer {
 	return v.sequencer
 }
 
+func (v *Visp) Macros() *macro.Registers {
+	return v.macros
+}
+
 func (v *Visp) Multibar() *multibar.Multibar {
 	return v.multibar
 }
@@ -130,7 +156,11 @@ func (v *Visp) SetList(lst list.List) {
 	c := v.db.Cache(lst)
 	v.db.SetCursor(c)
 	v.list = lst
-	v.Termui.TableWidget().SetList(lst)
+	v.UI().TableWidget().SetList(lst)
+	if lst == list.List(v.nowPlaying) {
+		v.updateNowPlaying()
+	}
+	v.broadcastList(lst)
 }
 
 func (v *Visp) Spotify() (*spotify.Client, error) {
@@ -149,6 +179,10 @@ func (v *Visp) Styles() style.Stylesheet {
 	return v.stylesheet
 }
 
+func (v *Visp) StyleRules() *stylerule.Rules {
+	return v.styleRules
+}
+
 func (v *Visp) UI() api.UI {
-	return v.Termui
+	return v.ui
 }
@@ -0,0 +1,73 @@
+package prog
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ambientsound/visp/log"
+	spotify_proxyclient "github.com/ambientsound/visp/spotify/proxyclient"
+)
+
+// Batch runs commands from a script without a user interface.
+//
+// Each command runs to completion, including any commands and callbacks it queues,
+// before the next command starts. Execution stops at the first error, or when
+// the script runs the quit command.
+func (v *Visp) Batch(ctx context.Context, reader io.Reader) error {
+	// The main loop refreshes the access token in the background, but batch scripts
+	// don't have a main loop. Make sure the token lasts while the script runs.
+	if v.client != nil && spotify_proxyclient.TokenTTL(v.Tokencache.Cached()) <= 0 {
+		log.Debugf("Spotify access token has expired, refreshing...")
+		err := v.refreshToken()
+		if err != nil {
+			return fmt.Errorf("refresh Spotify access token: %w", err)
+		}
+	}
+
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		if ctx.Err() != nil {
+			return fmt.Errorf("killed by signal")
+		}
+
+		err := v.interpreter.Exec(scanner.Text())
+		if err == nil {
+			err = v.settle(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		select {
+		case <-v.quit:
+			return nil
+		default:
+		}
+	}
+
+	return scanner.Err()
+}
+
+// settle runs queued commands and callbacks until there is no more work pending.
+// Background work that has not delivered its callback yet is waited for.
+func (v *Visp) settle(ctx context.Context) error {
+	for len(v.commands) > 0 || len(v.callbacks) > 0 || v.pending > 0 {
+		select {
+		case command := <-v.commands:
+			err := v.Exec(command)
+			if err != nil {
+				return err
+			}
+		case callback := <-v.callbacks:
+			err := callback()
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("killed by signal")
+		}
+	}
+	return nil
+}
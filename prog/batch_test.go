@@ -0,0 +1,33 @@
+package prog_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/prog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	v := &prog.Visp{}
+	v.Init()
+
+	t.Run("commands run in order", func(t *testing.T) {
+		err := v.Batch(context.Background(), strings.NewReader("# comment\nset limit=5\n\nset limit=7\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, 7, options.GetInt(options.Limit))
+	})
+
+	t.Run("execution stops at the first error", func(t *testing.T) {
+		err := v.Batch(context.Background(), strings.NewReader("set limit=1\nfoo\nset limit=2\n"))
+		assert.EqualError(t, err, "line 2: not a command: foo")
+		assert.Equal(t, 1, options.GetInt(options.Limit))
+	})
+
+	t.Run("quit stops execution", func(t *testing.T) {
+		err := v.Batch(context.Background(), strings.NewReader("quit\nfoo\n"))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+package prog
+
+import (
+	"strings"
+
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/pkg/control"
+)
+
+// ListenControl starts the control socket, allowing external programs
+// to run commands in this instance.
+func (v *Visp) ListenControl(path string) error {
+	server, err := control.Listen(path)
+	if err != nil {
+		return err
+	}
+
+	v.control = server
+	v.controlRequests = server.Requests()
+	go server.Serve()
+
+	log.Infof("Listening for commands on %s", server.Path())
+
+	return nil
+}
+
+// CloseControl shuts down the control socket, if it is running.
+func (v *Visp) CloseControl() {
+	if v.control == nil {
+		return
+	}
+	err := v.control.Close()
+	if err != nil {
+		log.Errorf("Close control socket: %s", err)
+	}
+	v.control = nil
+	v.controlRequests = nil
+}
+
+// controlResponse runs a command received on the control socket.
+// The status query returns the player state instead.
+func (v *Visp) controlResponse(line string) control.Response {
+	if strings.TrimSpace(line) == control.StatusQuery {
+		return control.Response{
+			Result: v.player.Summary(),
+		}
+	}
+
+	err := v.Exec(line)
+	if err != nil {
+		log.Errorf("Control socket: %s", err)
+		return control.Response{
+			Error: err.Error(),
+		}
+	}
+
+	return control.Response{}
+}
@@ -0,0 +1,32 @@
+package prog
+
+import (
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/hooks"
+	"github.com/ambientsound/visp/player"
+)
+
+// runHooks compares the previous and current player state,
+// and runs the user-defined hooks for any transitions.
+func (v *Visp) runHooks(prev player.State) {
+	cur := *v.player
+
+	if prev.TrackRow.ID() != cur.TrackRow.ID() {
+		v.runHook(hooks.SongChange, options.HookSongChange)
+	}
+	if prev.State() != cur.State() {
+		v.runHook(hooks.StateChange, options.HookStateChange)
+	}
+	if prev.Device.ID != cur.Device.ID {
+		v.runHook(hooks.DeviceChange, options.HookDeviceChange)
+	}
+}
+
+func (v *Visp) runHook(event, option string) {
+	command := options.GetString(option)
+	err := hooks.Run(event, command, v.player.Summary())
+	if err != nil {
+		log.Errorf("Run %s hook: %s", event, err)
+	}
+}
@@ -0,0 +1,54 @@
+package prog
+
+import (
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/pkg/httpapi"
+)
+
+// ListenHTTP starts the HTTP API, allowing remote clients with the given token
+// to control this instance.
+func (v *Visp) ListenHTTP(address, token string) error {
+	server := httpapi.New(token)
+	err := server.Listen(address)
+	if err != nil {
+		return err
+	}
+
+	v.httpapi = server
+	v.httpRequests = server.Requests()
+	go server.Serve()
+
+	log.Infof("HTTP API listening on http://%s", server.Addr())
+
+	return nil
+}
+
+// CloseHTTP shuts down the HTTP API, if it is running.
+func (v *Visp) CloseHTTP() {
+	if v.httpapi == nil {
+		return
+	}
+	err := v.httpapi.Close()
+	if err != nil {
+		log.Errorf("Close HTTP API: %s", err)
+	}
+	v.httpapi = nil
+	v.httpRequests = nil
+}
+
+// broadcastPlayer sends the player state to HTTP API event listeners.
+func (v *Visp) broadcastPlayer() {
+	if v.httpapi == nil {
+		return
+	}
+	v.httpapi.Broadcast(httpapi.EventPlayer, v.player.Summary())
+}
+
+// broadcastList notifies HTTP API event listeners that a list has changed.
+func (v *Visp) broadcastList(lst list.List) {
+	if v.httpapi == nil {
+		return
+	}
+	v.httpapi.Broadcast(httpapi.EventList, httpapi.NewListInfo(lst))
+}
@@ -0,0 +1,35 @@
+package prog
+
+import (
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/pkg/mpris"
+)
+
+// ConnectMpris exposes the MPRIS2 interface on the D-Bus session bus.
+func (v *Visp) ConnectMpris() error {
+	server, err := mpris.Connect()
+	if err != nil {
+		return err
+	}
+
+	v.mpris = server
+	v.mprisCommands = server.Commands()
+	v.mpris.Update(*v.player)
+
+	log.Debugf("MPRIS interface available on D-Bus session bus")
+
+	return nil
+}
+
+// CloseMpris disconnects from the D-Bus session bus, if connected.
+func (v *Visp) CloseMpris() {
+	if v.mpris == nil {
+		return
+	}
+	err := v.mpris.Close()
+	if err != nil {
+		log.Errorf("Close MPRIS connection: %s", err)
+	}
+	v.mpris = nil
+	v.mprisCommands = nil
+}
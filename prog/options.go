@@ -1,6 +1,8 @@
 package prog
 
 import (
+	"fmt"
+
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/options"
 	"github.com/ambientsound/visp/pkg/library"
@@ -26,13 +28,36 @@ func (v *Visp) optionChanged(key string) {
 	case options.Topbar:
 		config := options.GetString(options.Topbar)
 		matrix, err := topbar.Parse(v, config)
-		if err == nil {
+		if err != nil {
+			log.Errorf("topbar configuration: %s", err)
+		} else if v.Termui != nil {
 			v.Termui.Widgets.Topbar.SetMatrix(matrix)
 			v.Termui.Resize()
-		} else {
-			log.Errorf("topbar configuration: %s", err)
 		}
 
+	case options.Statusline:
+		config := options.GetString(options.Statusline)
+		matrix, err := topbar.Parse(v, config)
+		if err == nil && (len(matrix.Rows) > 1 || len(matrix.Rows) == 1 && len(matrix.Rows[0].Pieces) > 2) {
+			err = fmt.Errorf("expected a single line with at most two pieces")
+		}
+		if err != nil {
+			log.Errorf("statusline configuration: %s", err)
+		} else if v.Termui != nil {
+			var row *topbar.RowStatement
+			if len(matrix.Rows) > 0 {
+				row = matrix.Rows[0]
+			}
+			v.Termui.Widgets.Multibar.SetStatusline(row)
+		}
+
+	case options.AlbumArt, options.AlbumArtProtocol, options.AlbumArtWidth:
+		if v.Termui != nil {
+			v.Termui.Resize()
+			v.Termui.Refresh()
+		}
+		v.updateAlbumArt()
+
 	case options.Database:
 		const optionMemory = "memory"
 		const optionFilesystem = "filesystem"
@@ -64,8 +89,31 @@ func (v *Visp) optionChanged(key string) {
 
 		v.index = idx
 
+	case options.Tabbar, options.InfoPanel, options.InfoPanelWidth:
+		if v.Termui != nil {
+			v.Termui.Resize()
+		}
+
+	case options.Mouse:
+		if v.Termui != nil {
+			v.Termui.SetMouse(options.GetBool(options.Mouse))
+		}
+
 	case options.ExpandColumns:
-		// Re-render columns
-		v.UI().TableWidget().SetColumns(v.UI().TableWidget().ColumnNames())
+		v.renderColumns()
+
+	default:
+		if options.IsColumnOption(key) {
+			v.renderColumns()
+		}
+	}
+}
+
+// renderColumns recalculates column widths and settings in all panes.
+func (v *Visp) renderColumns() {
+	for _, table := range v.UI().Panes().TableWidgets() {
+		if table.List() != nil {
+			table.SetColumns(table.ColumnNames())
+		}
 	}
 }
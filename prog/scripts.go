@@ -0,0 +1,36 @@
+package prog
+
+import (
+	"path/filepath"
+
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/pkg/script"
+)
+
+// LoadScripts runs all script files in a directory,
+// and registers the commands they define.
+func (v *Visp) LoadScripts(dir string) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+script.Extension))
+	if err != nil {
+		log.Errorf("Find scripts in %s: %s", dir, err)
+		return
+	}
+
+	for _, path := range paths {
+		verbs, err := script.Load(v, path)
+		if err != nil {
+			log.Errorf("Load script %s: %s", path, err)
+			continue
+		}
+
+		for _, verb := range verbs {
+			err = commands.Register(verb.Name, verb.Constructor(), verb.Description())
+			if err != nil {
+				log.Errorf("Load script %s: %s", path, err)
+			}
+		}
+
+		log.Infof("Loaded %d commands from %s", len(verbs), path)
+	}
+}
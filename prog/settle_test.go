@@ -0,0 +1,47 @@
+package prog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettle(t *testing.T) {
+	v := &Visp{}
+	v.Init()
+
+	t.Run("background work is waited for", func(t *testing.T) {
+		release := make(chan struct{})
+		done := false
+		v.background(func() func() error {
+			<-release
+			return func() error {
+				done = true
+				return nil
+			}
+		})
+
+		time.AfterFunc(50*time.Millisecond, func() {
+			close(release)
+		})
+		assert.NoError(t, v.settle(context.Background()))
+		assert.True(t, done)
+	})
+
+	t.Run("waiting stops when the context is done", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		v.background(func() func() error {
+			<-release
+			return func() error {
+				return nil
+			}
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.Error(t, v.settle(ctx))
+	})
+}
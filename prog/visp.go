@@ -3,6 +3,7 @@ package prog
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,11 +22,22 @@ import (
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/multibar"
 	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/albumart"
+	"github.com/ambientsound/visp/pkg/control"
+	"github.com/ambientsound/visp/pkg/editor"
+	"github.com/ambientsound/visp/pkg/httpapi"
 	"github.com/ambientsound/visp/pkg/library"
+	"github.com/ambientsound/visp/pkg/macro"
+	"github.com/ambientsound/visp/pkg/mpris"
 	"github.com/ambientsound/visp/pkg/search"
+	"github.com/ambientsound/visp/pkg/stylerule"
 	"github.com/ambientsound/visp/player"
+	spotify_info "github.com/ambientsound/visp/spotify/info"
 	"github.com/ambientsound/visp/spotify/library"
+	"github.com/ambientsound/visp/spotify/likes"
+	spotify_nowplaying "github.com/ambientsound/visp/spotify/nowplaying"
 	spotify_proxyclient "github.com/ambientsound/visp/spotify/proxyclient"
+	spotify_queue "github.com/ambientsound/visp/spotify/queue"
 	spotify_tracklist "github.com/ambientsound/visp/spotify/tracklist"
 	"github.com/ambientsound/visp/style"
 	"github.com/ambientsound/visp/tabcomplete"
@@ -44,26 +56,55 @@ const (
 )
 
 type Visp struct {
+	// Termui is the terminal user interface. It is nil in batch mode,
+	// which runs commands with Batch instead of Main.
 	Termui     *widgets.Application
 	Tokencache tokencache.Tokencache
 
+	albumArt     *albumart.Cache
+	albumArtURL  string
 	client       *spotify.Client
 	clipboards   *clipboard.List
 	commands     chan string
+	contextURI   spotify.URI
+	control      *control.Server
 	db           *db.List
 	history      list.List
+	httpapi      *httpapi.Server
 	index        library.Index
 	interpreter  *input.Interpreter
 	library      *spotify_library.List
+	likes        *spotify_likes.Cache
 	list         list.List
 	callbacks    chan func() error
+	macros       *macro.Registers
+	mpris        *mpris.Server
 	multibar     *multibar.Multibar
+	nowPlaying   *spotify_nowplaying.List
 	player       *player.State
+	queueFetch   bool
 	quit         chan interface{}
 	sequencer    *keys.Sequencer
 	stylesheet   style.Stylesheet
+	styleRules   *stylerule.Rules
 	ticker       *time.Ticker
 	tokenRefresh <-chan time.Time
+	ui           api.UI
+
+	// whichKeyTimeout fires when the pending key bindings popup should be hidden.
+	whichKeyTimeout <-chan time.Time
+
+	// controlRequests is nil unless the control socket is running.
+	controlRequests <-chan control.Request
+
+	// httpRequests is nil unless the HTTP API is running.
+	httpRequests <-chan httpapi.Request
+
+	// mprisCommands is nil unless connected to D-Bus.
+	mprisCommands <-chan string
+
+	// pending counts background work whose callback has not run yet.
+	pending int
 }
 
 var _ api.API = &Visp{}
@@ -72,13 +113,18 @@ func (v *Visp) Init() {
 	tcf := func(in string) multibar.TabCompleter {
 		return tabcomplete.New(in, v)
 	}
+	v.albumArt = albumart.NewCache(albumart.DefaultDir())
 	v.clipboards = clipboard.New()
 	v.callbacks = make(chan func() error, 16)
 	v.commands = make(chan string, 1024)
 	v.db = db.New()
 	v.interpreter = input.NewCLI(v)
 	v.library = spotify_library.New()
+	v.likes = spotify_likes.New()
+	v.macros = macro.New("")
+	v.styleRules = stylerule.New()
 	v.multibar = multibar.New(tcf)
+	v.nowPlaying = spotify_nowplaying.New()
 	v.player = player.NewState(spotify.PlayerState{})
 	v.quit = make(chan interface{}, 1)
 	v.sequencer = keys.NewSequencer()
@@ -86,10 +132,22 @@ func (v *Visp) Init() {
 	v.ticker = time.NewTicker(tickerInterval)
 	v.tokenRefresh = make(chan time.Time)
 
+	// Without a terminal, run with an invisible user interface.
+	if v.Termui != nil {
+		v.ui = v.Termui
+	} else {
+		v.ui = widgets.NewHeadless(v)
+	}
+
 	v.SetList(log.List(log.InfoLevel))
 }
 
+// Main runs the interactive main loop until the program quits. It requires a user interface.
 func (v *Visp) Main(ctx context.Context) error {
+	if v.Termui == nil {
+		return fmt.Errorf("the main loop requires a user interface")
+	}
+
 	defer v.index.Close()
 
 	// searchCancel() is called any time a search query string arrives.
@@ -115,8 +173,15 @@ func (v *Visp) Main(ctx context.Context) error {
 					v.tokenRefresh = time.After(refreshInvalidTokenDeploy)
 				}
 			}
+			if v.mpris != nil {
+				v.mpris.Update(*v.player)
+			}
+			v.updateNowPlaying()
 			v.ticker.Reset(tickerInterval)
 
+		case <-v.whichKeyTimeout:
+			v.Termui.WhichKey().Hide()
+
 		case <-v.tokenRefresh:
 			log.Infof("Spotify access token is too old, refreshing...")
 			err := v.refreshToken()
@@ -134,6 +199,26 @@ func (v *Visp) Main(ctx context.Context) error {
 		case command := <-v.multibar.Commands():
 			v.commands <- command
 
+		// Edit the command line in an external editor.
+		case text := <-v.multibar.Edits():
+			v.editCommandLine(text)
+
+		// Commands received on the control socket.
+		case req := <-v.controlRequests:
+			req.Reply <- v.controlResponse(req.Line)
+
+		// Requests from HTTP API clients.
+		case req := <-v.httpRequests:
+			req.Reply <- req.Execute(v)
+
+		// Commands from desktop media keys and widgets.
+		case command := <-v.mprisCommands:
+			v.commands <- command
+
+		// Commands triggered by mouse clicks.
+		case command := <-v.Termui.Commands():
+			v.commands <- command
+
 		// Search input box.
 		case query := <-v.multibar.Searches():
 			if len(query) == 0 {
@@ -166,11 +251,7 @@ func (v *Visp) Main(ctx context.Context) error {
 
 		// Process the command queue.
 		case command := <-v.commands:
-			err := v.Exec(command)
-			if err != nil {
-				log.Errorf(err.Error())
-				v.multibar.Error(err)
-			}
+			v.run(command, 0)
 
 		case ev := <-v.Termui.Events():
 			// First try to handle basic terminal events, such as resize.
@@ -184,15 +265,23 @@ func (v *Visp) Main(ctx context.Context) error {
 				break
 			}
 
+			// Commands such as `macro record` may wait for a register name.
+			if v.registerKey(ev) {
+				break
+			}
+
 			// Add the key event to the sequencer, which will determine if a keybinding was pressed.
-			cmd := v.keyEventCommand(ev)
-			if len(cmd) == 0 {
+			// Counts typed before the key sequence are passed on to the command.
+			match := v.keyEventCommand(ev)
+			if match == nil {
 				break
 			}
-			v.commands <- cmd
+			v.run(match.Command, match.Count)
 		}
 
 		// Draw UI after processing any event.
+		v.updateInfoPanel()
+		v.updateLikes()
 		v.Termui.Draw()
 	}
 
@@ -224,11 +313,303 @@ func (v *Visp) updateLiked() error {
 	}
 
 	v.player.SetLiked(liked[0])
+	v.likes.Set(v.player.Item.ID.String(), liked[0])
 	log.Debugf("Likes current track: %v", v.player.Liked())
 
 	return nil
 }
 
+// background runs fn in a goroutine, and the callback it returns on the main loop.
+// Outstanding work is counted, so that batch scripts can wait for it to finish.
+func (v *Visp) background(fn func() func() error) {
+	v.pending++
+	go func() {
+		callback := fn()
+		v.callbacks <- func() error {
+			v.pending--
+			return callback()
+		}
+	}()
+}
+
+// Record the next track in the playback queue.
+// The queue is downloaded in the background, and recorded when ready.
+func (v *Visp) updateQueue() {
+	if v.queueFetch {
+		return
+	}
+
+	client, err := v.Spotify()
+	if err != nil {
+		return
+	}
+
+	v.queueFetch = true
+
+	v.background(func() func() error {
+		queue, err := spotify_queue.Get(context.TODO(), client)
+		return func() error {
+			v.queueFetch = false
+			if err != nil {
+				v.player.NextRow = nil
+				log.Debugf("Unable to fetch playback queue: %s", err)
+				return nil
+			}
+			next := queue.Next()
+			if next == nil {
+				v.player.NextRow = nil
+			} else {
+				v.player.NextRow = spotify_tracklist.FullTrackRow(*next)
+			}
+			return nil
+		}
+	})
+}
+
+// Show the cover image of the current album in the album art pane, if enabled.
+// Images are downloaded in the background, and shown when ready.
+func (v *Visp) updateAlbumArt() {
+	if v.Termui == nil {
+		return
+	}
+
+	url := ""
+	if options.GetBool(options.AlbumArt) {
+		url = albumart.ImageURL(v.player.Item)
+	}
+	if url == v.albumArtURL {
+		return
+	}
+
+	v.albumArtURL = url
+	v.Termui.Widgets.AlbumArt.SetImage(nil)
+	if len(url) == 0 {
+		return
+	}
+
+	v.background(func() func() error {
+		img, err := v.albumArt.Get(context.TODO(), url)
+		return func() error {
+			if err != nil {
+				return fmt.Errorf("album art: %s", err)
+			}
+			// Ignore images that arrive after the track has changed.
+			if url == v.albumArtURL {
+				v.Termui.Widgets.AlbumArt.SetImage(img)
+			}
+			return nil
+		}
+	})
+}
+
+// Show the current player state in the now playing window. While the window is visible,
+// audio features and analysis of new tracks are downloaded in the background.
+func (v *Visp) updateNowPlaying() {
+	v.nowPlaying.Update(*v.player)
+
+	id := v.nowPlaying.TrackID()
+	if v.list != list.List(v.nowPlaying) || len(id) == 0 || id == v.nowPlaying.AudioID() {
+		return
+	}
+
+	client, err := v.Spotify()
+	if err != nil {
+		return
+	}
+
+	// Mark the track as requested, so that it is only requested once.
+	v.nowPlaying.SetAudio(id, nil, nil, nil)
+
+	v.background(func() func() error {
+		var features *spotify.AudioFeatures
+		var analysis *spotify.AudioAnalysis
+
+		all, err := client.GetAudioFeatures(context.TODO(), spotify.ID(id))
+		if err == nil {
+			if len(all) > 0 {
+				features = all[0]
+			}
+			analysis, err = client.GetAudioAnalysis(context.TODO(), spotify.ID(id))
+		}
+		if err != nil {
+			analysis = nil
+			err = fmt.Errorf("audio analysis unavailable: %s", err)
+		}
+
+		return func() error {
+			if id == v.nowPlaying.AudioID() {
+				v.nowPlaying.SetAudio(id, features, analysis, err)
+			}
+			return nil
+		}
+	})
+}
+
+// Look up more information about the row shown in the info panel, in the background.
+func (v *Visp) updateInfoPanel() {
+	panel := v.Termui.Widgets.Info
+	row := panel.Wanted()
+	if row == nil {
+		return
+	}
+
+	client, err := v.Spotify()
+	if err != nil {
+		return
+	}
+
+	id := row.ID()
+	panel.SetExtras(id, nil)
+
+	v.background(func() func() error {
+		extras, err := spotify_info.Extras(context.TODO(), client, row)
+		return func() error {
+			if err != nil {
+				log.Debugf("Unable to look up information about '%s': %s", id, err)
+				extras = map[string]string{}
+			}
+			panel.SetExtras(id, extras)
+			return nil
+		}
+	})
+}
+
+// Look up whether the tracks shown on screen are liked, if any style rule needs to know.
+// Tracks that could not be looked up are not tried again.
+func (v *Visp) updateLikes() {
+	if !v.styleRules.Uses("liked") {
+		return
+	}
+
+	ids := make([]string, 0)
+	for _, widget := range v.Termui.Panes().TableWidgets() {
+		lst := widget.List()
+		if lst == nil {
+			continue
+		}
+		ymin, ymax := widget.GetVisibleBoundaries()
+		for y := ymin; y <= ymax; y++ {
+			row := lst.Row(y)
+			if row != nil && row.Kind() == list.DataTypeTrack {
+				ids = append(ids, row.ID())
+			}
+		}
+	}
+
+	wanted := v.likes.Wanted(ids)
+	if len(wanted) == 0 {
+		return
+	}
+
+	client, err := v.Spotify()
+	if err != nil {
+		v.likes.Forget(wanted...)
+		return
+	}
+
+	v.background(func() func() error {
+		trackIDs := make([]spotify.ID, len(wanted))
+		for i := range wanted {
+			trackIDs[i] = spotify.ID(wanted[i])
+		}
+		liked, err := client.UserHasTracks(context.TODO(), trackIDs...)
+		return func() error {
+			if err != nil {
+				log.Debugf("Unable to look up liked status of %d tracks: %s", len(wanted), err)
+				return nil
+			}
+			for i := range liked {
+				v.likes.Set(wanted[i], liked[i])
+			}
+			return nil
+		}
+	})
+}
+
+// Record the name of the playlist, album or artist that the current track is playing from.
+// Names that must be looked up are downloaded in the background, and the lookup is
+// tried again on the next poll until the name is known.
+func (v *Visp) updateContext(prevURI spotify.URI) {
+	playbackContext := v.player.PlaybackContext
+	if playbackContext.URI != prevURI {
+		v.player.ContextName = ""
+	}
+	if len(v.player.ContextName) > 0 || playbackContext.URI == v.contextURI {
+		return
+	}
+
+	parts := strings.Split(string(playbackContext.URI), ":")
+	id := spotify.ID(parts[len(parts)-1])
+	if len(id) == 0 {
+		return
+	}
+
+	// Liked songs are played from the user's collection, which has no name.
+	if playbackContext.Type == "collection" {
+		v.player.ContextName = "Liked songs"
+		return
+	}
+
+	// The album name is already known if the current track is from the album.
+	item := v.player.Item
+	if playbackContext.Type == "album" && item != nil && item.Album.URI == playbackContext.URI {
+		v.player.ContextName = item.Album.Name
+		return
+	}
+
+	client, err := v.Spotify()
+	if err != nil {
+		return
+	}
+
+	// Only one lookup runs at a time for each context.
+	uri := playbackContext.URI
+	v.contextURI = uri
+
+	v.background(func() func() error {
+		name, err := contextName(client, playbackContext.Type, id)
+		return func() error {
+			if v.contextURI == uri {
+				v.contextURI = ""
+			}
+			if err != nil {
+				log.Debugf("Unable to look up playback context: %s", err)
+				return nil
+			}
+			// Ignore names that arrive after the context has changed.
+			if uri == v.player.PlaybackContext.URI {
+				v.player.ContextName = name
+			}
+			return nil
+		}
+	})
+}
+
+// contextName returns the name of a playlist, album or artist.
+func contextName(client *spotify.Client, typ string, id spotify.ID) (string, error) {
+	switch typ {
+	case "album":
+		album, err := client.GetAlbum(context.TODO(), id)
+		if err != nil {
+			return "", err
+		}
+		return album.Name, nil
+	case "artist":
+		artist, err := client.GetArtist(context.TODO(), id)
+		if err != nil {
+			return "", err
+		}
+		return artist.Name, nil
+	case "playlist":
+		playlist, err := client.GetPlaylist(context.TODO(), id, spotify.Fields("name"))
+		if err != nil {
+			return "", err
+		}
+		return playlist.Name, nil
+	}
+	return "", nil
+}
+
 func (v *Visp) updatePlayer() error {
 	var err error
 
@@ -254,6 +635,7 @@ func (v *Visp) updatePlayer() error {
 	}
 
 	currentID := spotify.ID(v.player.TrackRow.ID())
+	prev := *v.player
 
 	v.player.Update(*state)
 
@@ -267,37 +649,138 @@ func (v *Visp) updatePlayer() error {
 		v.History().Add(spotify_tracklist.FullTrackRow(*state.Item))
 	}
 
-	if v.player.LikedIsKnown() {
-		return nil
+	v.updateContext(prev.PlaybackContext.URI)
+	v.updateQueue()
+	v.updateAlbumArt()
+
+	// Hooks are run after the liked status is known, so that a new track's status is passed on.
+	var likedErr error
+	if !v.player.LikedIsKnown() {
+		likedErr = v.updateLiked()
+		if likedErr != nil {
+			likedErr = fmt.Errorf("get liked status of current song: %s", likedErr)
+		}
+	}
+
+	v.runHooks(prev)
+	v.broadcastPlayer()
+
+	return likedErr
+}
+
+// run executes a command given by the user, and records it if a macro is being recorded.
+func (v *Visp) run(command string, count int) {
+	err := v.ExecCount(command, count)
+	if err != nil {
+		log.Errorf(err.Error())
+		v.multibar.Error(err)
+		return
 	}
+	// Commands waiting for a register name record themselves once the register is known.
+	if v.macros.Awaiting() {
+		return
+	}
+	v.macros.Add(macro.Entry{
+		Command: command,
+		Count:   count,
+	})
+}
 
-	err = v.updateLiked()
+// editCommandLine runs the user's text editor on the command line text, and puts the result back on the command line.
+// The editor has the terminal to itself until it exits.
+func (v *Visp) editCommandLine(text string) {
+	if v.Termui == nil {
+		return
+	}
+
+	err := v.Termui.Suspend()
 	if err != nil {
-		return fmt.Errorf("get liked status of current song: %s", err)
+		log.Errorf("Suspend terminal: %s", err)
+		return
 	}
 
-	return nil
+	edited, err := editor.Edit(text)
+
+	resumeErr := v.Termui.Resume()
+	if resumeErr != nil {
+		log.Errorf("Resume terminal: %s", resumeErr)
+	}
+
+	if err != nil {
+		log.Errorf("Edit command line with %s: %s", editor.Command(), err)
+		return
+	}
+
+	v.multibar.SetText(edited)
+}
+
+// registerKey passes a key press to a command waiting for a register name.
+// Returns true if the key press was consumed.
+func (v *Visp) registerKey(event tcell.Event) bool {
+	ev, ok := event.(*tcell.EventKey)
+	if !ok || !v.macros.Awaiting() {
+		return false
+	}
+	if ev.Key() != tcell.KeyRune {
+		v.macros.Await(nil)
+		return true
+	}
+	err := v.macros.Key(string(ev.Rune()))
+	if err != nil {
+		log.Errorf(err.Error())
+		v.multibar.Error(err)
+	}
+	return true
+}
+
+// LoadMacros reads macro registers from a file, and stores recorded macros there.
+func (v *Visp) LoadMacros(path string) {
+	v.macros = macro.New(path)
+	err := v.macros.Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Errorf("Unable to read macros: %s", err)
+	}
 }
 
 // KeyInput receives key input signals, checks the sequencer for key bindings,
 // and runs commands if key bindings are found.
-func (v *Visp) keyEventCommand(event tcell.Event) string {
+func (v *Visp) keyEventCommand(event tcell.Event) *keys.Binding {
 	ev, ok := event.(*tcell.EventKey)
 	if !ok {
-		return ""
+		return nil
 	}
 
 	contexts := commands.Contexts(v)
 	v.sequencer.KeyInput(ev, contexts)
 	match := v.sequencer.Match(contexts)
+	v.updateWhichKey(contexts)
 
 	if match == nil {
-		return ""
+		return nil
 	}
 
 	log.Debugf("Input sequencer matches bind: '%s' -> '%s'", match.Sequence, match.Command)
 
-	return match.Command
+	return match
+}
+
+// updateWhichKey shows the key bindings that can complete the current input sequence,
+// or hides them if there is no input sequence.
+func (v *Visp) updateWhichKey(contexts []string) {
+	popup := v.Termui.WhichKey()
+	if !options.GetBool(options.WhichKey) {
+		popup.Hide()
+		return
+	}
+
+	popup.Set(len(v.sequencer.Input()), v.sequencer.Pending(contexts))
+
+	timeout := options.GetInt(options.WhichKeyTimeout)
+	if popup.Visible() && timeout > 0 {
+		v.whichKeyTimeout = time.After(time.Millisecond * time.Duration(timeout))
+	} else {
+		v.whichKeyTimeout = nil
+	}
 }
 
 // SourceDefaultConfig reads, parses, and executes the default config.
@@ -2,11 +2,14 @@ package spotify_aggregator
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/ambientsound/visp/list"
 	"github.com/ambientsound/visp/options"
 	spotify_albums "github.com/ambientsound/visp/spotify/albums"
 	"github.com/ambientsound/visp/spotify/library"
+	"github.com/ambientsound/visp/spotify/link"
 	"github.com/ambientsound/visp/spotify/playlists"
 	"github.com/ambientsound/visp/spotify/tracklist"
 	"github.com/zmb3/spotify/v2"
@@ -17,6 +20,7 @@ func Search(client spotify.Client, query string, limit int) (list.List, error) {
 		context.TODO(),
 		query,
 		spotify.SearchTypeTrack,
+		spotify.Market(spotify.MarketFromToken),
 		spotify.Limit(limit),
 	)
 	if err != nil {
@@ -65,7 +69,7 @@ func ListWithID(client spotify.Client, id string, limit int) (list.List, error)
 		return nil, err
 	}
 
-	tracks, err := client.GetPlaylistTracks(context.TODO(), sid, spotify.Limit(limit))
+	tracks, err := client.GetPlaylistTracks(context.TODO(), sid, spotify.Market(spotify.MarketFromToken), spotify.Limit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +112,7 @@ func MyPrivatePlaylists(client spotify.Client, limit int) (*spotify_playlists.Li
 }
 
 func MyTracks(client spotify.Client, limit int) (list.List, error) {
-	tracks, err := client.CurrentUsersTracks(context.TODO(), spotify.Limit(limit))
+	tracks, err := client.CurrentUsersTracks(context.TODO(), spotify.Market(spotify.MarketFromToken), spotify.Limit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -188,3 +192,120 @@ func NewReleases(client spotify.Client) (list.List, error) {
 
 	return lst, nil
 }
+
+// Open loads the list that a Spotify link points to. Albums, playlists and shows are opened as tracklists,
+// and artists as a list of their albums. Tracks are shown in their album, with the cursor on the track.
+func Open(client spotify.Client, lnk spotify_link.Link, limit int) (list.List, error) {
+	switch lnk.Type {
+	case spotify_link.TypeAlbum:
+		return Album(client, lnk.ID)
+	case spotify_link.TypeArtist:
+		return ArtistAlbums(client, lnk.ID, limit)
+	case spotify_link.TypePlaylist:
+		return ListWithID(client, lnk.ID.String(), limit)
+	case spotify_link.TypeShow:
+		return Show(client, lnk.ID, limit)
+	case spotify_link.TypeTrack:
+		return TrackAlbum(client, lnk.ID)
+	default:
+		return nil, fmt.Errorf("cannot open Spotify links of type '%s'", lnk.Type)
+	}
+}
+
+func Album(client spotify.Client, id spotify.ID) (list.List, error) {
+	album, err := client.GetAlbum(context.TODO(), id, spotify.Market(spotify.MarketFromToken))
+	if err != nil {
+		return nil, err
+	}
+
+	lst, err := spotify_tracklist.NewFromSimpleTrackPage(client, &album.Tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	lst.SetName(fmt.Sprintf("%s - %s", strings.Join(artistNames(album.Artists), ", "), album.Name))
+	lst.SetID(string(album.URI))
+	lst.SetVisibleColumns(options.GetList(options.ColumnsTracklists))
+
+	// don't sort albums, their track order is significant.
+	lst.SetCursor(0)
+
+	return lst, nil
+}
+
+// TrackAlbum returns the album of a track, with the cursor on the track.
+func TrackAlbum(client spotify.Client, id spotify.ID) (list.List, error) {
+	track, err := client.GetTrack(context.TODO(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	lst, err := Album(client, track.Album.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = lst.SetCursorByID(id.String())
+
+	return lst, nil
+}
+
+func ArtistAlbums(client spotify.Client, id spotify.ID, limit int) (list.List, error) {
+	artist, err := client.GetArtist(context.TODO(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	types := []spotify.AlbumType{spotify.AlbumTypeAlbum, spotify.AlbumTypeSingle, spotify.AlbumTypeCompilation}
+	albums, err := client.GetArtistAlbums(context.TODO(), id, types, spotify.Market(spotify.MarketFromToken), spotify.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	lst, err := spotify_albums.NewFromSimpleAlbumPage(client, albums)
+	if err != nil {
+		return nil, err
+	}
+
+	lst.SetName(fmt.Sprintf("Albums by %s", artist.Name))
+	lst.SetID(string(artist.URI))
+	lst.SetVisibleColumns(options.GetList(options.ColumnsAlbums))
+	lst.Sort(options.GetList(options.SortAlbums))
+	lst.SetCursor(0)
+
+	return lst, nil
+}
+
+func Show(client spotify.Client, id spotify.ID, limit int) (list.List, error) {
+	show, err := client.GetShow(context.TODO(), id, spotify.Market(spotify.MarketFromToken))
+	if err != nil {
+		return nil, err
+	}
+
+	episodes, err := client.GetShowEpisodes(context.TODO(), id.String(), spotify.Market(spotify.MarketFromToken), spotify.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	lst, err := spotify_tracklist.NewFromSimpleEpisodePage(client, episodes, show.SimpleShow)
+	if err != nil {
+		return nil, err
+	}
+
+	lst.SetName(show.Name)
+	lst.SetID(string(show.URI))
+	lst.SetVisibleColumns(options.GetList(options.ColumnsTracklists))
+
+	// episodes are listed newest first.
+	lst.SetCursor(0)
+
+	return lst, nil
+}
+
+func artistNames(artists []spotify.SimpleArtist) []string {
+	names := make([]string, len(artists))
+	for i := range artists {
+		names[i] = artists[i].Name
+	}
+	return names
+}
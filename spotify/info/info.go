@@ -0,0 +1,157 @@
+// Package spotify_info looks up details about tracks, albums and playlists
+// that are not included in the lists they are shown in.
+//
+// The record label of an album is not supported by the Spotify client library,
+// so albums are requested directly using the client's access token.
+package spotify_info
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/spotify/tracklist"
+	"github.com/zmb3/spotify/v2"
+)
+
+const requestTimeout = 10 * time.Second
+
+// baseURL is the URL of the Spotify Web API, and is replaced in tests.
+var baseURL = "https://api.spotify.com/v1/"
+
+// album holds the fields of an album that the Spotify client library does not provide.
+type album struct {
+	Label       string `json:"label"`
+	ReleaseDate string `json:"release_date"`
+	TotalTracks int    `json:"total_tracks"`
+	Artists     []struct {
+		ID spotify.ID `json:"id"`
+	} `json:"artists"`
+	Copyrights []spotify.Copyright `json:"copyrights"`
+}
+
+// Supported returns true if Extras can look up more information about a row.
+func Supported(row list.Row) bool {
+	switch row.Kind() {
+	case list.DataTypeAlbum, list.DataTypePlaylist:
+		return len(row.ID()) > 0
+	case list.DataTypeTrack:
+		_, ok := row.(*spotify_tracklist.Row)
+		return ok && len(row.ID()) > 0
+	default:
+		return false
+	}
+}
+
+// Extras returns more information about a track, album or playlist row:
+// the album's release date, record label and copyright, the artists' genres,
+// or the playlist's description, owner and number of followers.
+func Extras(ctx context.Context, client *spotify.Client, row list.Row) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	switch row.Kind() {
+	case list.DataTypeTrack:
+		track, ok := row.(*spotify_tracklist.Row)
+		if !ok {
+			return nil, fmt.Errorf("no track information available")
+		}
+		return albumExtras(ctx, client, track.Track().Album.ID)
+	case list.DataTypeAlbum:
+		return albumExtras(ctx, client, spotify.ID(row.ID()))
+	case list.DataTypePlaylist:
+		return playlistExtras(ctx, client, spotify.ID(row.ID()))
+	default:
+		return nil, fmt.Errorf("no information available about %s rows", row.Kind())
+	}
+}
+
+func albumExtras(ctx context.Context, client *spotify.Client, id spotify.ID) (map[string]string, error) {
+	token, err := client.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"albums/"+string(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	token.SetAuthHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get album: %s", resp.Status)
+	}
+
+	a := &album{}
+	err = json.NewDecoder(resp.Body).Decode(a)
+	if err != nil {
+		return nil, fmt.Errorf("decode album: %s", err)
+	}
+
+	extras := map[string]string{
+		"label":       a.Label,
+		"releaseDate": a.ReleaseDate,
+		"totalTracks": fmt.Sprintf("%d", a.TotalTracks),
+	}
+	if len(a.Copyrights) > 0 {
+		extras["copyright"] = a.Copyrights[0].Text
+	}
+
+	ids := make([]spotify.ID, 0, len(a.Artists))
+	for _, artist := range a.Artists {
+		ids = append(ids, artist.ID)
+	}
+	if len(ids) == 0 {
+		return extras, nil
+	}
+
+	artists, err := client.GetArtists(ctx, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("get artists: %s", err)
+	}
+	extras["genres"] = strings.Join(genres(artists), ", ")
+
+	return extras, nil
+}
+
+func playlistExtras(ctx context.Context, client *spotify.Client, id spotify.ID) (map[string]string, error) {
+	playlist, err := client.GetPlaylist(ctx, id, spotify.Fields("description,followers.total,owner.display_name"))
+	if err != nil {
+		return nil, fmt.Errorf("get playlist: %s", err)
+	}
+	return map[string]string{
+		"description": playlist.Description,
+		"followers":   fmt.Sprintf("%d", playlist.Followers.Count),
+		"owner":       playlist.Owner.DisplayName,
+	}, nil
+}
+
+// genres returns the genres of all artists, sorted and without duplicates.
+func genres(artists []*spotify.FullArtist) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+	for _, artist := range artists {
+		if artist == nil {
+			continue
+		}
+		for _, genre := range artist.Genres {
+			if !seen[genre] {
+				seen[genre] = true
+				result = append(result, genre)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,77 @@
+package spotify_info
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/spotify/tracklist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+	"golang.org/x/oauth2"
+)
+
+func TestExtras(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/albums/abbeyroad":
+			_, _ = w.Write([]byte(`{
+				"label": "Apple Records",
+				"release_date": "1969-09-26",
+				"total_tracks": 17,
+				"artists": [{"id": "beatles"}],
+				"copyrights": [{"text": "© 2019 Calderstone Productions", "type": "C"}]
+			}`))
+		case "/artists":
+			assert.Equal(t, "beatles", r.URL.Query().Get("ids"))
+			_, _ = w.Write([]byte(`{"artists": [{"id": "beatles", "genres": ["rock", "british invasion", "rock"]}]}`))
+		case "/playlists/mix":
+			_, _ = w.Write([]byte(`{"description": "Songs to code to", "followers": {"total": 42}, "owner": {"display_name": "Kim"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	baseURL = server.URL + "/"
+	httpClient := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret"}))
+	client := spotify.New(httpClient, spotify.WithBaseURL(server.URL+"/"))
+
+	track := spotify.FullTrack{SimpleTrack: spotify.SimpleTrack{ID: "something"}}
+	track.Album.ID = "abbeyroad"
+	row := spotify_tracklist.FullTrackRow(track)
+	require.True(t, Supported(row))
+
+	extras, err := Extras(context.Background(), client, row)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"copyright":   "© 2019 Calderstone Productions",
+		"genres":      "british invasion, rock",
+		"label":       "Apple Records",
+		"releaseDate": "1969-09-26",
+		"totalTracks": "17",
+	}, extras)
+
+	extras, err = Extras(context.Background(), client, list.NewRow("abbeyroad", list.DataTypeAlbum, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "Apple Records", extras["label"])
+
+	extras, err = Extras(context.Background(), client, list.NewRow("mix", list.DataTypePlaylist, nil))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"description": "Songs to code to",
+		"followers":   "42",
+		"owner":       "Kim",
+	}, extras)
+
+	_, err = Extras(context.Background(), client, list.NewRow("missing", list.DataTypeAlbum, nil))
+	assert.Error(t, err)
+
+	// Rows without a Spotify object behind them are not supported.
+	assert.False(t, Supported(list.NewRow("1", list.DataTypeTrack, nil)))
+	assert.False(t, Supported(list.NewRow("1", list.DataTypeLogLine, nil)))
+}
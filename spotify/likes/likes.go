@@ -0,0 +1,60 @@
+// Package spotify_likes remembers which tracks are in the user's library of liked songs.
+//
+// Spotify only answers this question for a few tracks at a time, so the status of
+// each track is looked up once, when it is needed, and kept for the rest of the session.
+package spotify_likes
+
+// MaxLookup is the number of tracks that Spotify can be asked about in a single request.
+const MaxLookup = 50
+
+// Cache holds the liked status of tracks, keyed by track ID.
+type Cache struct {
+	liked   map[string]bool
+	pending map[string]bool
+}
+
+// New returns an empty cache.
+func New() *Cache {
+	return &Cache{
+		liked:   make(map[string]bool),
+		pending: make(map[string]bool),
+	}
+}
+
+// Get returns whether a track is liked, and whether its status is known at all.
+func (c *Cache) Get(id string) (liked bool, known bool) {
+	liked, known = c.liked[id]
+	return
+}
+
+// Set records whether a track is liked.
+func (c *Cache) Set(id string, liked bool) {
+	c.liked[id] = liked
+	delete(c.pending, id)
+}
+
+// Wanted returns up to MaxLookup of the given tracks whose status is unknown.
+// The returned tracks are assumed to be looked up, and are not returned again
+// until they are either Set or Forget.
+func (c *Cache) Wanted(ids []string) []string {
+	wanted := make([]string, 0, MaxLookup)
+	for _, id := range ids {
+		if len(wanted) == MaxLookup {
+			break
+		}
+		if _, known := c.liked[id]; known || c.pending[id] || len(id) == 0 {
+			continue
+		}
+		c.pending[id] = true
+		wanted = append(wanted, id)
+	}
+	return wanted
+}
+
+// Forget clears the status of tracks, so that they are looked up again when needed.
+func (c *Cache) Forget(ids ...string) {
+	for _, id := range ids {
+		delete(c.liked, id)
+		delete(c.pending, id)
+	}
+}
@@ -0,0 +1,50 @@
+package spotify_likes_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ambientsound/visp/spotify/likes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	cache := spotify_likes.New()
+
+	_, known := cache.Get("a")
+	assert.False(t, known)
+
+	// Tracks that are being looked up are not wanted again.
+	assert.Equal(t, []string{"a", "b"}, cache.Wanted([]string{"a", "", "b"}))
+	assert.Equal(t, []string{"c"}, cache.Wanted([]string{"a", "b", "c"}))
+
+	cache.Set("a", true)
+	cache.Set("b", false)
+
+	liked, known := cache.Get("a")
+	assert.True(t, known)
+	assert.True(t, liked)
+
+	liked, known = cache.Get("b")
+	assert.True(t, known)
+	assert.False(t, liked)
+
+	// Forgotten tracks are looked up again.
+	cache.Forget("a", "c")
+	_, known = cache.Get("a")
+	assert.False(t, known)
+	assert.Equal(t, []string{"a", "c"}, cache.Wanted([]string{"a", "b", "c"}))
+}
+
+func TestCacheWantedLimit(t *testing.T) {
+	cache := spotify_likes.New()
+
+	ids := make([]string, spotify_likes.MaxLookup+10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("track%d", i)
+	}
+
+	assert.Equal(t, ids[:spotify_likes.MaxLookup], cache.Wanted(ids))
+	assert.Equal(t, ids[spotify_likes.MaxLookup:], cache.Wanted(ids))
+	assert.Empty(t, cache.Wanted(ids))
+}
@@ -0,0 +1,136 @@
+// Package spotify_link converts between Spotify URIs and open.spotify.com URLs.
+//
+// Links are accepted in any of the forms that the Spotify clients share them as:
+//
+//	spotify:album:<id>
+//	spotify:user:<user>:playlist:<id>
+//	https://open.spotify.com/album/<id>?si=<token>
+//	https://open.spotify.com/intl-de/track/<id>
+//	open.spotify.com/user/<user>/playlist/<id>
+package spotify_link
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/zmb3/spotify/v2"
+)
+
+// BaseURL is the address of the Spotify web player.
+const BaseURL = "https://open.spotify.com/"
+
+// Types of Spotify objects that links may point to.
+const (
+	TypeAlbum    = "album"
+	TypeArtist   = "artist"
+	TypePlaylist = "playlist"
+	TypeShow     = "show"
+	TypeTrack    = "track"
+)
+
+// Link points to a single Spotify object.
+type Link struct {
+	Type string
+	ID   spotify.ID
+}
+
+// Parse returns the Spotify object a URL or URI points to.
+func Parse(s string) (*Link, error) {
+	s = strings.TrimSpace(s)
+
+	var parts []string
+	switch {
+	case strings.HasPrefix(s, "spotify:"):
+		parts = strings.Split(s, ":")[1:]
+	default:
+		var err error
+		parts, err = urlPath(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("'%s' is not a Spotify link", s)
+	}
+
+	// Playlists may be prefixed with their owner, as in `user/<user>/playlist/<id>`,
+	// so only the last two parts are significant.
+	link := &Link{
+		Type: parts[len(parts)-2],
+		ID:   spotify.ID(parts[len(parts)-1]),
+	}
+
+	switch link.Type {
+	case TypeAlbum, TypeArtist, TypePlaylist, TypeShow, TypeTrack:
+	default:
+		return nil, fmt.Errorf("cannot open Spotify links of type '%s'", link.Type)
+	}
+
+	if !validID(string(link.ID)) {
+		return nil, fmt.Errorf("'%s' is not a valid Spotify ID", link.ID)
+	}
+
+	return link, nil
+}
+
+// urlPath returns the path components of an open.spotify.com URL, without any language prefix.
+func urlPath(s string) ([]string, error) {
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a Spotify link", s)
+	}
+
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, fmt.Errorf("'%s' is not a Spotify link", s)
+	case u.Host != "open.spotify.com" && u.Host != "play.spotify.com":
+		return nil, fmt.Errorf("'%s' is not a Spotify link", s)
+	}
+
+	parts := make([]string, 0)
+	for _, part := range strings.Split(u.Path, "/") {
+		if len(part) == 0 || part == "embed" || strings.HasPrefix(part, "intl-") {
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
+
+// validID returns true if s consists of base-62 characters only.
+func validID(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// URI returns the Spotify URI of the linked object.
+func (link Link) URI() spotify.URI {
+	return spotify.URI("spotify:" + link.Type + ":" + string(link.ID))
+}
+
+// URL returns the web player URL of a Spotify URI, such as
+// `https://open.spotify.com/track/<id>` for `spotify:track:<id>`.
+// An empty string is returned if the URI is not valid.
+func URL(uri spotify.URI) string {
+	parts := strings.Split(string(uri), ":")
+	if len(parts) < 3 || parts[0] != "spotify" {
+		return ""
+	}
+	return BaseURL + strings.Join(parts[1:], "/")
+}
@@ -0,0 +1,53 @@
+package spotify_link_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/spotify/link"
+	"github.com/stretchr/testify/assert"
+	"github.com/zmb3/spotify/v2"
+)
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		uri   spotify.URI
+	}{
+		{"spotify:album:1DFixLWuPkv3KT3TnV35m3", "spotify:album:1DFixLWuPkv3KT3TnV35m3"},
+		{"spotify:artist:0OdUWJ0sBjDrqHygGUXeCF", "spotify:artist:0OdUWJ0sBjDrqHygGUXeCF"},
+		{"spotify:user:someone:playlist:37i9dQZF1DXcBWIGoYBM5M", "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M"},
+		{"https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC?si=a1b2c3d4", "spotify:track:4uLU6hMCjMI75M1A2tKUQC"},
+		{"https://open.spotify.com/intl-de/album/1DFixLWuPkv3KT3TnV35m3", "spotify:album:1DFixLWuPkv3KT3TnV35m3"},
+		{"http://open.spotify.com/user/someone/playlist/37i9dQZF1DXcBWIGoYBM5M", "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M"},
+		{"open.spotify.com/show/5CfCWKI5pZ28U0uOzXkDHe", "spotify:show:5CfCWKI5pZ28U0uOzXkDHe"},
+		{"  https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC\n", "spotify:track:4uLU6hMCjMI75M1A2tKUQC"},
+	} {
+		link, err := spotify_link.Parse(test.input)
+		if assert.NoError(t, err, test.input) {
+			assert.Equal(t, test.uri, link.URI())
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"foo",
+		"spotify:",
+		"spotify:track",
+		"spotify:user:someone",
+		"spotify:track:not-an-id",
+		"https://example.com/track/4uLU6hMCjMI75M1A2tKUQC",
+		"ftp://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC",
+		"https://open.spotify.com/",
+		"https://open.spotify.com/genre/0JQ5DAqbMKFQ00XGBls6ym",
+	} {
+		_, err := spotify_link.Parse(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestURL(t *testing.T) {
+	assert.Equal(t, "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC", spotify_link.URL("spotify:track:4uLU6hMCjMI75M1A2tKUQC"))
+	assert.Equal(t, "", spotify_link.URL("track:4uLU6hMCjMI75M1A2tKUQC"))
+}
@@ -0,0 +1,274 @@
+// Package spotify_nowplaying implements a window showing everything known about the currently playing track.
+//
+// Besides the track metadata, the window shows the track's audio features, and a loudness
+// timeline built from its audio analysis. Each section of the analysis is drawn as one row,
+// with a playhead marking the current playback position.
+package spotify_nowplaying
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/pkg/format"
+	"github.com/ambientsound/visp/player"
+	"github.com/ambientsound/visp/utils"
+	"github.com/zmb3/spotify/v2"
+)
+
+// Sections of the window.
+const (
+	SectionTrack    = "track"
+	SectionFeatures = "features"
+	SectionAnalysis = "analysis"
+)
+
+const (
+	// Resolution is the number of seconds of audio represented by one character in the loudness timeline.
+	Resolution = 2.0
+
+	// Playhead marks the current playback position in the loudness timeline.
+	Playhead = '┃'
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+var keyNames = []string{"C", "C♯", "D", "E♭", "E", "F", "F♯", "G", "A♭", "A", "B♭", "B"}
+
+var columns = []string{"section", "name", "value"}
+
+// List shows details about the currently playing track.
+type List struct {
+	list.Base
+	state player.State
+
+	// Audio features and analysis of the track with ID audioID.
+	audioID  string
+	features *spotify.AudioFeatures
+	analysis *spotify.AudioAnalysis
+	audioErr error
+
+	// timelines holds the loudness timeline of each analysis section, without the playhead.
+	timelines []string
+}
+
+var _ list.List = &List{}
+
+// New returns an empty List.
+func New() *List {
+	this := &List{
+		state: *player.NewState(spotify.PlayerState{}),
+	}
+	this.rebuild()
+	this.SetID("nowplaying")
+	this.SetName("Now playing")
+	return this
+}
+
+// Update shows the current player state. Rows are rebuilt if the track has changed,
+// otherwise only the playback position and liked status are updated.
+func (l *List) Update(state player.State) {
+	changed := state.TrackRow.ID() != l.state.TrackRow.ID()
+	l.state = state
+	if changed {
+		l.rebuild()
+	} else {
+		l.refresh()
+	}
+}
+
+// TrackID returns the Spotify ID of the track being shown.
+func (l *List) TrackID() string {
+	return l.state.TrackRow.ID()
+}
+
+// AudioID returns the Spotify ID of the track that audio features and analysis have been given for.
+func (l *List) AudioID() string {
+	return l.audioID
+}
+
+// SetAudio sets the audio features and analysis for a track, or the error that prevented retrieving them.
+// Any of the arguments may be nil.
+func (l *List) SetAudio(id string, features *spotify.AudioFeatures, analysis *spotify.AudioAnalysis, err error) {
+	l.audioID = id
+	l.features = features
+	l.analysis = analysis
+	l.audioErr = err
+	l.rebuild()
+}
+
+// rebuild replaces all rows with information about the current track, keeping the cursor position.
+func (l *List) rebuild() {
+	cursor := l.Cursor()
+	l.Clear()
+	l.timelines = nil
+
+	fields := l.state.TrackRow.Fields()
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		l.add(SectionTrack, key, fields[key])
+	}
+	if len(l.state.TrackRow.ID()) > 0 {
+		l.add(SectionTrack, "uri", string(l.state.TrackRow.URI()))
+		l.add(SectionTrack, "liked", "unknown")
+	}
+
+	if l.audioID == l.state.TrackRow.ID() && len(l.audioID) > 0 {
+		if l.audioErr != nil {
+			l.add(SectionAnalysis, "error", l.audioErr.Error())
+		}
+		if l.features != nil {
+			l.addFeatures(*l.features)
+		}
+		if l.analysis != nil {
+			l.addAnalysis(*l.analysis)
+		}
+	}
+
+	l.SetVisibleColumns(columns)
+	l.SetCursor(cursor)
+	l.refresh()
+}
+
+// refresh updates the rows that change during playback.
+func (l *List) refresh() {
+	if row := l.RowByID(SectionTrack + ".liked"); row != nil {
+		liked := "unknown"
+		if l.state.LikedIsKnown() {
+			liked = utils.HumanFormatBool(l.state.Liked())
+		}
+		row.Set("value", liked)
+	}
+
+	// Rows may have been sorted or removed, so they are looked up by ID.
+	position := float64(l.state.Progress) / 1000
+	for i, timeline := range l.timelines {
+		row := l.RowByID(analysisID(i))
+		if row == nil {
+			continue
+		}
+		section := l.analysis.Sections[i]
+		playing := position >= section.Start && position < section.Start+section.Duration
+		row.Set("playing", utils.HumanFormatBool(playing))
+		if !playing {
+			row.Set("value", timeline)
+			continue
+		}
+		runes := []rune(timeline)
+		index := int((position - section.Start) / Resolution)
+		if index < len(runes) {
+			runes[index] = Playhead
+		}
+		row.Set("value", string(runes))
+	}
+}
+
+func (l *List) add(section, name, value string) {
+	l.Add(newRow(section+"."+name, section, name, value))
+}
+
+func newRow(id, section, name, value string) list.Row {
+	return list.NewRow(id, list.DataTypeInfo, map[string]string{
+		"section": section,
+		"name":    name,
+		"value":   value,
+	})
+}
+
+func (l *List) addFeatures(features spotify.AudioFeatures) {
+	l.add(SectionFeatures, "acousticness", fmt.Sprintf("%1.2f", features.Acousticness))
+	l.add(SectionFeatures, "danceability", fmt.Sprintf("%1.2f", features.Danceability))
+	l.add(SectionFeatures, "energy", fmt.Sprintf("%1.2f", features.Energy))
+	l.add(SectionFeatures, "instrumentalness", fmt.Sprintf("%1.2f", features.Instrumentalness))
+	l.add(SectionFeatures, "key", KeyName(features.Key, features.Mode))
+	l.add(SectionFeatures, "liveness", fmt.Sprintf("%1.2f", features.Liveness))
+	l.add(SectionFeatures, "loudness", fmt.Sprintf("%.1f dB", features.Loudness))
+	l.add(SectionFeatures, "speechiness", fmt.Sprintf("%1.2f", features.Speechiness))
+	l.add(SectionFeatures, "tempo", fmt.Sprintf("%.0f BPM", features.Tempo))
+	l.add(SectionFeatures, "timeSignature", fmt.Sprintf("%d/4", features.TimeSignature))
+	l.add(SectionFeatures, "valence", fmt.Sprintf("%1.2f", features.Valence))
+}
+
+func (l *List) addAnalysis(analysis spotify.AudioAnalysis) {
+	low, high := loudnessRange(analysis.Segments)
+	for i, section := range analysis.Sections {
+		timeline := Timeline(analysis.Segments, section.Start, section.Start+section.Duration, low, high)
+		start := format.Duration(strconv.Itoa(int(section.Start)))
+		row := newRow(analysisID(i), SectionAnalysis, start, timeline)
+		row.Set("key", KeyName(int(section.Key), int(section.Mode)))
+		row.Set("loudness", fmt.Sprintf("%.1f dB", section.Loudness))
+		row.Set("playing", utils.HumanFormatBool(false))
+		row.Set("tempo", fmt.Sprintf("%.0f BPM", section.Tempo))
+		l.Add(row)
+		l.timelines = append(l.timelines, timeline)
+	}
+}
+
+// analysisID returns the row ID of an analysis section.
+func analysisID(index int) string {
+	return fmt.Sprintf("%s.%d", SectionAnalysis, index)
+}
+
+// KeyName returns the name of a musical key, such as `F♯ minor`.
+func KeyName(key, mode int) string {
+	if key < 0 || key >= len(keyNames) {
+		return "unknown"
+	}
+	if mode == int(spotify.Major) {
+		return keyNames[key] + " major"
+	}
+	return keyNames[key] + " minor"
+}
+
+// Timeline returns the loudness between start and end seconds as a bar graph, with one character per Resolution seconds.
+// The loudest segment within each character's time span decides its height, on a scale from low to high decibels.
+func Timeline(segments []spotify.Segment, start, end, low, high float64) string {
+	width := int(math.Ceil((end - start) / Resolution))
+	peaks := make([]float64, width)
+	for i := range peaks {
+		peaks[i] = math.Inf(-1)
+	}
+
+	for _, segment := range segments {
+		if segment.Start+segment.Duration <= start || segment.Start >= end {
+			continue
+		}
+		from := utils.Max(0, int((segment.Start-start)/Resolution))
+		to := utils.Min(width-1, int(math.Ceil((segment.Start+segment.Duration-start)/Resolution))-1)
+		for i := from; i <= to; i++ {
+			peaks[i] = math.Max(peaks[i], segment.LoudnessMax)
+		}
+	}
+
+	runes := make([]rune, width)
+	for i, peak := range peaks {
+		level := 0.0
+		if high > low && !math.IsInf(peak, -1) {
+			level = (peak - low) / (high - low)
+		}
+		index := int(math.Round(level * float64(len(sparks)-1)))
+		runes[i] = sparks[utils.Max(0, utils.Min(len(sparks)-1, index))]
+	}
+
+	return string(runes)
+}
+
+// loudnessRange returns the lowest and highest peak loudness of all segments.
+func loudnessRange(segments []spotify.Segment) (float64, float64) {
+	if len(segments) == 0 {
+		return 0, 0
+	}
+	low, high := segments[0].LoudnessMax, segments[0].LoudnessMax
+	for _, segment := range segments {
+		low = math.Min(low, segment.LoudnessMax)
+		high = math.Max(high, segment.LoudnessMax)
+	}
+	return low, high
+}
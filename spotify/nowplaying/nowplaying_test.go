@@ -0,0 +1,125 @@
+package spotify_nowplaying_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ambientsound/visp/player"
+	"github.com/ambientsound/visp/spotify/nowplaying"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+)
+
+func playing(progress int) player.State {
+	state := player.NewState(spotify.PlayerState{
+		CurrentlyPlaying: spotify.CurrentlyPlaying{
+			Item: &spotify.FullTrack{
+				SimpleTrack: spotify.SimpleTrack{
+					ID:       "abc",
+					Name:     "Come Together",
+					Duration: 20000,
+				},
+			},
+			Progress: progress,
+		},
+	})
+	state.SetLiked(true)
+	return *state
+}
+
+func analysis() *spotify.AudioAnalysis {
+	segment := func(start, loudness float64) spotify.Segment {
+		return spotify.Segment{Marker: spotify.Marker{Start: start, Duration: 2}, LoudnessMax: loudness}
+	}
+	return &spotify.AudioAnalysis{
+		Sections: []spotify.Section{
+			{Marker: spotify.Marker{Start: 0, Duration: 8}, Key: spotify.D, Mode: spotify.Minor},
+			{Marker: spotify.Marker{Start: 8, Duration: 12}, Key: spotify.A, Mode: spotify.Major},
+		},
+		Segments: []spotify.Segment{
+			segment(0, -40), segment(2, -30), segment(4, -20), segment(6, -10),
+			segment(8, -5), segment(10, -5), segment(12, -40), segment(14, -40),
+			segment(16, -40), segment(18, -40),
+		},
+	}
+}
+
+func TestNowPlaying(t *testing.T) {
+	lst := spotify_nowplaying.New()
+	assert.Equal(t, 0, lst.Len())
+	assert.Equal(t, []string{"section", "name", "value"}, lst.VisibleColumns())
+
+	lst.Update(playing(0))
+	assert.Equal(t, "abc", lst.TrackID())
+	assert.Equal(t, "Come Together", lst.RowByID("track.title").Get("value"))
+	assert.Equal(t, "spotify:track:abc", lst.RowByID("track.uri").Get("value"))
+	assert.Equal(t, "yes", lst.RowByID("track.liked").Get("value"))
+
+	// Audio for other tracks is ignored.
+	lst.SetAudio("other", &spotify.AudioFeatures{}, analysis(), nil)
+	assert.Nil(t, lst.RowByID("features.key"))
+
+	lst.SetAudio("abc", &spotify.AudioFeatures{Key: 6, Mode: 1, Tempo: 120, TimeSignature: 4}, analysis(), nil)
+	assert.Equal(t, "F♯ major", lst.RowByID("features.key").Get("value"))
+	assert.Equal(t, "120 BPM", lst.RowByID("features.tempo").Get("value"))
+	assert.Equal(t, "4/4", lst.RowByID("features.timeSignature").Get("value"))
+
+	first := lst.RowByID("analysis.0")
+	second := lst.RowByID("analysis.1")
+	require.NotNil(t, first)
+	require.NotNil(t, second)
+	assert.Equal(t, "0:00", first.Get("name"))
+	assert.Equal(t, "0:08", second.Get("name"))
+	assert.Equal(t, "D minor", first.Get("key"))
+	assert.Equal(t, "┃▃▅▇", first.Get("value"))
+	assert.Equal(t, "yes", first.Get("playing"))
+	assert.Equal(t, "██▁▁▁▁", second.Get("value"))
+	assert.Equal(t, "no", second.Get("playing"))
+
+	// The playhead follows the playback position.
+	lst.SetCursor(3)
+	lst.Update(playing(10500))
+	assert.Equal(t, "▁▃▅▇", first.Get("value"))
+	assert.Equal(t, "no", first.Get("playing"))
+	assert.Equal(t, "█┃▁▁▁▁", second.Get("value"))
+	assert.Equal(t, "yes", second.Get("playing"))
+	assert.Equal(t, 3, lst.Cursor())
+
+	// A new track clears the audio analysis.
+	next := playing(0)
+	next.Item.ID = "def"
+	next.Update(next.PlayerState)
+	lst.Update(next)
+	assert.Nil(t, lst.RowByID("analysis.0"))
+
+	lst.SetAudio("def", nil, nil, fmt.Errorf("forbidden"))
+	assert.Equal(t, "forbidden", lst.RowByID("analysis.error").Get("value"))
+}
+
+// Timelines are updated in place after rows have been sorted or removed.
+func TestNowPlayingRowsChanged(t *testing.T) {
+	lst := spotify_nowplaying.New()
+	lst.Update(playing(0))
+	lst.SetAudio("abc", &spotify.AudioFeatures{}, analysis(), nil)
+
+	require.NoError(t, lst.Sort([]string{"value"}))
+	lst.Update(playing(10500))
+	assert.Equal(t, "▁▃▅▇", lst.RowByID("analysis.0").Get("value"))
+	assert.Equal(t, "█┃▁▁▁▁", lst.RowByID("analysis.1").Get("value"))
+	assert.Equal(t, "Come Together", lst.RowByID("track.title").Get("value"))
+
+	index, err := lst.RowNum("analysis.0")
+	require.NoError(t, err)
+	require.NoError(t, lst.Remove(index))
+	assert.NotPanics(t, func() {
+		lst.Update(playing(2000))
+	})
+	assert.Equal(t, "██▁▁▁▁", lst.RowByID("analysis.1").Get("value"))
+}
+
+func TestKeyName(t *testing.T) {
+	assert.Equal(t, "C major", spotify_nowplaying.KeyName(0, 1))
+	assert.Equal(t, "B♭ minor", spotify_nowplaying.KeyName(10, 0))
+	assert.Equal(t, "unknown", spotify_nowplaying.KeyName(-1, 0))
+}
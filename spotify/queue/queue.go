@@ -0,0 +1,69 @@
+// Package spotify_queue reads the user's playback queue.
+//
+// The queue endpoint is not supported by the Spotify client library,
+// so requests are made directly using the client's access token.
+package spotify_queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+)
+
+const requestTimeout = 10 * time.Second
+
+// endpoint is the URL of the Spotify Web API queue endpoint, and is replaced in tests.
+var endpoint = "https://api.spotify.com/v1/me/player/queue"
+
+// Queue is the user's playback queue.
+type Queue struct {
+	CurrentlyPlaying *spotify.FullTrack  `json:"currently_playing"`
+	Queue            []spotify.FullTrack `json:"queue"`
+}
+
+// Get returns the user's playback queue.
+func Get(ctx context.Context, client *spotify.Client) (*Queue, error) {
+	token, err := client.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	token.SetAuthHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get playback queue: %s", resp.Status)
+	}
+
+	queue := &Queue{}
+	err = json.NewDecoder(resp.Body).Decode(queue)
+	if err != nil {
+		return nil, fmt.Errorf("decode playback queue: %s", err)
+	}
+
+	return queue, nil
+}
+
+// Next returns the next track in the queue, or nil if the queue is empty.
+func (q *Queue) Next() *spotify.FullTrack {
+	if len(q.Queue) == 0 {
+		return nil
+	}
+	return &q.Queue[0]
+}
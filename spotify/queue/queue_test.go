@@ -0,0 +1,37 @@
+package spotify_queue
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+	"golang.org/x/oauth2"
+)
+
+func TestGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{
+			"currently_playing": {"id": "1", "name": "Come Together"},
+			"queue": [{"id": "2", "name": "Something"}, {"id": "3", "name": "Maxwell's Silver Hammer"}]
+		}`))
+	}))
+	defer server.Close()
+
+	endpoint = server.URL
+	httpClient := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret"}))
+	client := spotify.New(httpClient)
+
+	queue, err := Get(context.Background(), client)
+	require.NoError(t, err)
+	require.NotNil(t, queue.Next())
+	assert.Equal(t, "Something", queue.Next().Name)
+	assert.Equal(t, "Come Together", queue.CurrentlyPlaying.Name)
+
+	empty := &Queue{}
+	assert.Nil(t, empty.Next())
+}
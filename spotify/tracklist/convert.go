@@ -10,7 +10,8 @@ import (
 const maxFullTracks = 50
 
 // Convert a list of SimpleTrack objects to FullTrack.
-func SimpleTracksToFullTracks(client *spotify.Client, simpleTracks []spotify.SimpleTrack) ([]spotify.FullTrack, error) {
+// The request options, such as the market, are passed on to Spotify.
+func SimpleTracksToFullTracks(client *spotify.Client, simpleTracks []spotify.SimpleTrack, opts ...spotify.RequestOption) ([]spotify.FullTrack, error) {
 	ids := make([]spotify.ID, len(simpleTracks))
 	for i := range simpleTracks {
 		ids[i] = simpleTracks[i].ID
@@ -24,7 +25,7 @@ func SimpleTracksToFullTracks(client *spotify.Client, simpleTracks []spotify.Sim
 		if len(batch) > maxFullTracks {
 			batch = batch[:maxFullTracks]
 		}
-		tracks, err := client.GetTracks(context.TODO(), batch)
+		tracks, err := client.GetTracks(context.TODO(), batch, opts...)
 		if err != nil {
 			return nil, err
 		}
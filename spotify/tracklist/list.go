@@ -35,15 +35,15 @@ func NewFromFullTrackPage(client spotify.Client, source *spotify.FullTrackPage)
 	return NewFromTracks(tracks), nil
 }
 
-func NewFromSimpleTrackPageAndAlbum(client spotify.Client, source *spotify.SimpleTrackPage, album spotify.SimpleAlbum) (*List, error) {
+// NewFromSimpleTrackPage returns a list of all the tracks of an album.
+// Album tracks lack popularity and playability, so they are fetched again as full tracks in the user's market.
+func NewFromSimpleTrackPage(client spotify.Client, source *spotify.SimpleTrackPage) (*List, error) {
 	var err error
 
-	tracks := make([]spotify.FullTrack, 0, source.Total)
+	simpleTracks := make([]spotify.SimpleTrack, 0, source.Total)
 
 	for err == nil {
-		for _, track := range source.Tracks {
-			tracks = append(tracks, AlbumTrack(track, album))
-		}
+		simpleTracks = append(simpleTracks, source.Tracks...)
 		err = client.NextPage(context.TODO(), source)
 	}
 
@@ -51,6 +51,11 @@ func NewFromSimpleTrackPageAndAlbum(client spotify.Client, source *spotify.Simpl
 		return nil, err
 	}
 
+	tracks, err := SimpleTracksToFullTracks(&client, simpleTracks, spotify.Market(spotify.MarketFromToken))
+	if err != nil {
+		return nil, err
+	}
+
 	return NewFromTracks(tracks), nil
 }
 
@@ -93,13 +98,6 @@ func NewFromPlaylistTrackPage(client spotify.Client, source *spotify.PlaylistTra
 
 }
 
-func AlbumTrack(track spotify.SimpleTrack, album spotify.SimpleAlbum) spotify.FullTrack {
-	return spotify.FullTrack{
-		SimpleTrack: track,
-		Album:       album,
-	}
-}
-
 func NewFromSimpleAlbumPage(client spotify.Client, source *spotify.SimpleAlbumPage) (*List, error) {
 	var lst *List
 	var err error
@@ -113,11 +111,11 @@ func NewFromSimpleAlbumPage(client spotify.Client, source *spotify.SimpleAlbumPa
 
 	for i := 0; i < albums.Len(); i++ {
 		album := albums.Album(i)
-		trackPage, err = client.GetAlbumTracks(context.TODO(), album.ID)
+		trackPage, err = client.GetAlbumTracks(context.TODO(), album.ID, spotify.Market(spotify.MarketFromToken))
 		if err != nil {
 			break
 		}
-		lst, err = NewFromSimpleTrackPageAndAlbum(client, trackPage, *album)
+		lst, err = NewFromSimpleTrackPage(client, trackPage)
 		if err != nil {
 			break
 		}
@@ -131,6 +129,27 @@ func NewFromSimpleAlbumPage(client spotify.Client, source *spotify.SimpleAlbumPa
 	return NewFromTracks(tracks), nil
 }
 
+// NewFromSimpleEpisodePage returns a list of all the episodes of a show.
+func NewFromSimpleEpisodePage(client spotify.Client, source *spotify.SimpleEpisodePage, show spotify.SimpleShow) (*List, error) {
+	var err error
+
+	this := &List{}
+	this.Clear()
+
+	for err == nil {
+		for _, episode := range source.Episodes {
+			this.Add(EpisodeRow(episode, show))
+		}
+		err = client.NextPage(context.TODO(), source)
+	}
+
+	if err != spotify.ErrNoMorePages {
+		return nil, err
+	}
+
+	return this, nil
+}
+
 func NewFromTracks(tracks []spotify.FullTrack) *List {
 	this := &List{}
 	this.Clear()
@@ -150,11 +169,45 @@ func NewHistory() *List {
 }
 
 func FullTrackRow(track spotify.FullTrack) list.Row {
+	return trackRow(track, list.DataTypeTrack)
+}
+
+// EpisodeRow returns a row for a podcast episode. The show's publisher is used as the artist,
+// and the show itself as the album.
+func EpisodeRow(episode spotify.EpisodePage, show spotify.SimpleShow) list.Row {
+	isPlayable := episode.IsPlayable
+	row := trackRow(spotify.FullTrack{
+		SimpleTrack: spotify.SimpleTrack{
+			Artists:  []spotify.SimpleArtist{{Name: show.Publisher}},
+			Duration: episode.Duration_ms,
+			Explicit: episode.Explicit,
+			ID:       episode.ID,
+			Name:     episode.Name,
+			URI:      episode.URI,
+		},
+		Album: spotify.SimpleAlbum{
+			Name:                 show.Name,
+			Artists:              []spotify.SimpleArtist{{Name: show.Publisher}},
+			ReleaseDate:          episode.ReleaseDate,
+			ReleaseDatePrecision: episode.ReleaseDatePrecision,
+		},
+		IsPlayable: &isPlayable,
+	}, list.DataTypeEpisode)
+
+	fields := row.Fields()
+	delete(fields, "disc")
+	delete(fields, "popularity")
+	delete(fields, "track")
+
+	return row
+}
+
+func trackRow(track spotify.FullTrack, kind list.DataType) list.Row {
 	return &Row{
 		track: track,
 		BaseRow: list.NewRow(
 			track.ID.String(),
-			list.DataTypeTrack,
+			kind,
 			map[string]string{
 				"album":       track.Album.Name,
 				"albumArtist": strings.Join(artistNames(track.Album.Artists), ", "),
@@ -164,6 +217,8 @@ func FullTrackRow(track spotify.FullTrack) list.Row {
 				"title":       track.Name,
 				"track":       fmt.Sprintf("%02d", track.TrackNumber),
 				"disc":        fmt.Sprintf("%d", track.DiscNumber),
+				"explicit":    utils.HumanFormatBool(track.Explicit),
+				"playable":    utils.HumanFormatBool(track.IsPlayable == nil || *track.IsPlayable),
 				"popularity":  fmt.Sprintf("%1.2f", float64(track.Popularity)/100),
 				"year":        track.Album.ReleaseDateTime().Format("2006"),
 			},
@@ -25,6 +25,7 @@ var tabCompleteTests = []struct {
 		"sort",
 		"stop",
 		"style",
+		"stylerule",
 	}},
 	{"set", true, []string{}},
 	{"add ", true, []string{}},
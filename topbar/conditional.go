@@ -0,0 +1,73 @@
+package topbar
+
+import (
+	"unicode/utf8"
+)
+
+// Conditional draws one of two sets of fragments, depending on whether its condition has any text.
+type Conditional struct {
+	condition Fragment
+	then      []Fragment
+	otherwise []Fragment
+}
+
+// NewConditional returns Conditional.
+func NewConditional(condition Fragment, then, otherwise []Fragment) Fragment {
+	return &Conditional{condition, then, otherwise}
+}
+
+// branch returns the fragments that should be drawn.
+func (w *Conditional) branch() []Fragment {
+	if empty(w.condition.Text()) {
+		return w.otherwise
+	}
+	return w.then
+}
+
+// Text implements Fragment.
+func (w *Conditional) Text() (string, string) {
+	text, style := "", `topbar`
+	for i, segment := range w.Segments() {
+		if i == 0 {
+			style = segment.Style
+		}
+		text += segment.Text
+	}
+	return text, style
+}
+
+// Segments implements Segmented, so that each fragment in the branch is drawn using its own style.
+func (w *Conditional) Segments() []Segment {
+	segments := make([]Segment, 0)
+	for _, frag := range w.branch() {
+		segments = append(segments, fragmentSegments(frag)...)
+	}
+	return segments
+}
+
+// Click implements Clickable by passing the click on to the fragment at that position.
+func (w *Conditional) Click(x, width int) string {
+	start := 0
+	for _, frag := range w.branch() {
+		text, _ := frag.Text()
+		fragWidth := utf8.RuneCountInString(text)
+		if x < start+fragWidth {
+			if clickable, ok := frag.(Clickable); ok {
+				return clickable.Click(x-start, fragWidth)
+			}
+			return ""
+		}
+		start += fragWidth
+	}
+	return ""
+}
+
+// fragmentSegments returns the segments of a fragment,
+// or its text as a single segment if the fragment has only one style.
+func fragmentSegments(frag Fragment) []Segment {
+	if segmented, ok := frag.(Segmented); ok {
+		return segmented.Segments()
+	}
+	text, style := frag.Text()
+	return []Segment{{text, style}}
+}
@@ -0,0 +1,20 @@
+package topbar
+
+import (
+	"github.com/ambientsound/visp/api"
+)
+
+// Context draws the name of the playlist, album or artist that the current track is playing from.
+type Context struct {
+	api api.API
+}
+
+// NewContext returns Context.
+func NewContext(a api.API, param string) Fragment {
+	return &Context{a}
+}
+
+// Text implements Fragment.
+func (w *Context) Text() (string, string) {
+	return w.api.PlayerStatus().ContextName, `playbackContext`
+}
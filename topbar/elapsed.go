@@ -39,3 +39,8 @@ func (w *Elapsed) textPercentage() (string, string) {
 	playerStatus := w.api.PlayerStatus()
 	return fmt.Sprintf("%3.f", playerStatus.ProgressPercentage*100), `elapsedPercentage`
 }
+
+// Click implements Clickable by seeking to the clicked position.
+func (w *Elapsed) Click(x, width int) string {
+	return seekCommand(w.api, x, width)
+}
@@ -0,0 +1,197 @@
+package topbar_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/player"
+	"github.com/ambientsound/visp/topbar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+)
+
+func playingAPI(progress float64) *api.MockAPI {
+	state := player.NewState(spotify.PlayerState{
+		CurrentlyPlaying: spotify.CurrentlyPlaying{
+			Item: &spotify.FullTrack{
+				SimpleTrack: spotify.SimpleTrack{Duration: 200000},
+			},
+		},
+	})
+	state.ProgressPercentage = progress
+	state.ContextName = "Abbey Road"
+	state.NextRow = list.NewRow("2", list.DataTypeTrack, map[string]string{"artist": "The Beatles", "title": "Something"})
+
+	a := &api.MockAPI{}
+	a.On("PlayerStatus").Return(*state)
+	return a
+}
+
+func TestProgress(t *testing.T) {
+	frag := topbar.NewProgress(playingAPI(0.25), "8")
+
+	text, style := frag.Text()
+	assert.Equal(t, "━━──────", text)
+	assert.Equal(t, "progressElapsed", style)
+
+	segments := frag.(topbar.Segmented).Segments()
+	assert.Equal(t, []topbar.Segment{{"━━", "progressElapsed"}, {"──────", "progressRemaining"}}, segments)
+
+	// Clicking the middle of the bar seeks to the middle of the track.
+	assert.Equal(t, "seek 100", frag.(topbar.Clickable).Click(4, 8))
+
+	// Invalid widths fall back to the default width.
+	text, _ = topbar.NewProgress(playingAPI(1.5), "foo").Text()
+	assert.Equal(t, "━━━━━━━━━━━━━━━━━━━━", text)
+}
+
+func TestNext(t *testing.T) {
+	text, style := topbar.NewNext(playingAPI(0), "").Text()
+	assert.Equal(t, "The Beatles - Something", text)
+	assert.Equal(t, "nextSong", style)
+
+	text, _ = topbar.NewNext(playingAPI(0), "title").Text()
+	assert.Equal(t, "Something", text)
+
+	a := &api.MockAPI{}
+	a.On("PlayerStatus").Return(*player.NewState(spotify.PlayerState{}))
+	text, _ = topbar.NewNext(a, "").Text()
+	assert.Equal(t, "", text)
+}
+
+func TestContext(t *testing.T) {
+	text, style := topbar.NewContext(playingAPI(0), "").Text()
+	assert.Equal(t, "Abbey Road", text)
+	assert.Equal(t, "playbackContext", style)
+}
+
+func TestConditional(t *testing.T) {
+	stopped := &api.MockAPI{}
+	stopped.On("PlayerStatus").Return(*player.NewState(spotify.PlayerState{}))
+
+	tests := []struct {
+		input   string
+		api     *api.MockAPI
+		text    string
+		command string
+	}{
+		{`${if context}from ${context}${else}nothing${end}`, playingAPI(0), "from Abbey Road", ""},
+		{`${if context}from ${context}${else}nothing${end}`, stopped, "nothing", ""},
+		{`${if tag|artist}${tag|artist} - ${end}$shortname`, stopped, "", ""},
+		{`${if next}${progress|4}${end}`, playingAPI(0.5), "━━──", "seek 150"},
+	}
+
+	for _, test := range tests {
+		matrix, err := topbar.Parse(test.api, test.input)
+		require.NoError(t, err, test.input)
+		frag := matrix.Rows[0].Pieces[0].Fragments[0].Instance
+		text, _ := frag.Text()
+		assert.Equal(t, test.text, text, test.input)
+		assert.Equal(t, test.command, frag.(topbar.Clickable).Click(3, 4), test.input)
+	}
+
+	// Each fragment in the branch keeps its own style.
+	matrix, err := topbar.Parse(playingAPI(0), `${if next}${next} ${context}${end}`)
+	require.NoError(t, err)
+	segments := matrix.Rows[0].Pieces[0].Fragments[0].Instance.(topbar.Segmented).Segments()
+	assert.Equal(t, []topbar.Segment{
+		{"The Beatles - Something", "nextSong"},
+		{" ", "topbar"},
+		{"Abbey Road", "playbackContext"},
+	}, segments)
+
+	_, err = topbar.Parse(stopped, `${end}`)
+	assert.Error(t, err)
+}
+
+func TestModifiers(t *testing.T) {
+	stopped := &api.MockAPI{}
+	stopped.On("PlayerStatus").Return(*player.NewState(spotify.PlayerState{}))
+
+	tests := []struct {
+		input string
+		api   *api.MockAPI
+		text  string
+	}{
+		{`${context|upper}`, playingAPI(0), "ABBEY ROAD"},
+		{`${context|lower}`, playingAPI(0), "abbey road"},
+		{`${context|max=6}`, playingAPI(0), "Abbey…"},
+		{`${context|pad=12}`, playingAPI(0), "Abbey Road  "},
+		{`${context|lpad=12}`, playingAPI(0), "  Abbey Road"},
+		{`${context|pad=3}`, playingAPI(0), "Abbey Road"},
+		{`${next|title|upper}`, playingAPI(0), "SOMETHING"},
+		{`${context|default=nothing}`, stopped, "nothing"},
+		{`${tag|artist|default=no artist}`, stopped, "no artist"},
+		{`${tag|artist|default=no artist|upper}`, stopped, "NO ARTIST"},
+	}
+
+	for _, test := range tests {
+		matrix, err := topbar.Parse(test.api, test.input)
+		require.NoError(t, err, test.input)
+		text, _ := matrix.Rows[0].Pieces[0].Fragments[0].Instance.Text()
+		assert.Equal(t, test.text, text, test.input)
+	}
+}
+
+func TestSelection(t *testing.T) {
+	lst := list.New()
+	lst.Add(list.NewRow("1", list.DataTypeTrack, map[string]string{"time": "03:30"}))
+	lst.Add(list.NewRow("2", list.DataTypeTrack, map[string]string{"time": "01:00:15"}))
+	lst.Add(list.NewRow("3", list.DataTypeTrack, map[string]string{"time": "02:00"}))
+
+	a := &api.MockAPI{}
+	a.On("List").Return(lst)
+
+	// Nothing is drawn until rows are selected, even though the cursor is on a row.
+	text, style := topbar.NewSelection(a, "count").Text()
+	assert.Equal(t, "", text)
+	assert.Equal(t, "selectionSummary", style)
+
+	lst.SetSelected(0, true)
+	lst.SetSelected(1, true)
+
+	text, _ = topbar.NewSelection(a, "").Text()
+	assert.Equal(t, "2", text)
+
+	text, _ = topbar.NewSelection(a, "time").Text()
+	assert.Equal(t, "1:03:45", text)
+}
+
+func TestReadout(t *testing.T) {
+	table := &api.MockTableWidget{}
+	table.On("List").Return(list.New())
+	table.On("PositionReadout").Return("All")
+
+	ui := &api.MockUI{}
+	ui.On("TableWidget").Return(table)
+
+	a := &api.MockAPI{}
+	a.On("UI").Return(ui)
+
+	text, style := topbar.NewReadout(a, "").Text()
+	assert.Equal(t, "All", text)
+	assert.Equal(t, "readout", style)
+}
+
+func TestSelectionConditional(t *testing.T) {
+	lst := list.New()
+	lst.Add(list.NewRow("1", list.DataTypeTrack, map[string]string{"time": "03:30"}))
+
+	a := &api.MockAPI{}
+	a.On("List").Return(lst)
+
+	matrix, err := topbar.Parse(a, `|${if selection}${selection|count} selected, ${selection|time}${end}`)
+	require.NoError(t, err)
+	require.Len(t, matrix.Rows, 1)
+	require.Len(t, matrix.Rows[0].Pieces, 2)
+
+	frag := matrix.Rows[0].Pieces[1].Fragments[0].Instance
+	text, _ := frag.Text()
+	assert.Equal(t, "", text)
+
+	lst.SetSelected(0, true)
+	text, _ = frag.Text()
+	assert.Equal(t, "1 selected, 3:30", text)
+}
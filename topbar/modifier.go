@@ -0,0 +1,106 @@
+package topbar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ambientsound/visp/pkg/format"
+	"github.com/ambientsound/visp/utils"
+)
+
+// Modifier names.
+const (
+	modifierDefault = "default"
+	modifierLower   = "lower"
+	modifierLpad    = "lpad"
+	modifierMax     = "max"
+	modifierPad     = "pad"
+	modifierUpper   = "upper"
+)
+
+// parseModifier splits a parameter into a modifier name and value, and returns
+// true if the parameter is a modifier.
+func parseModifier(param string) (*ModifierStatement, bool) {
+	name, value := param, ""
+	if i := strings.IndexByte(param, '='); i >= 0 {
+		name, value = param[:i], param[i+1:]
+	}
+	modifier := &ModifierStatement{Name: name, Value: value}
+	switch name {
+	case modifierDefault, modifierLower, modifierLpad, modifierMax, modifierPad, modifierUpper:
+		return modifier, true
+	}
+	return modifier, false
+}
+
+// validateModifier returns an error if a modifier is unknown or has an invalid value.
+func validateModifier(modifier *ModifierStatement) error {
+	switch modifier.Name {
+	case modifierDefault:
+		return nil
+	case modifierLower, modifierUpper:
+		if len(modifier.Value) > 0 {
+			return fmt.Errorf("Modifier '%s' does not take a value", modifier.Name)
+		}
+		return nil
+	case modifierLpad, modifierMax, modifierPad:
+		n, err := strconv.Atoi(modifier.Value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("Modifier '%s' requires a number, such as '%s=20'", modifier.Name, modifier.Name)
+		}
+		return nil
+	}
+	return fmt.Errorf("Unknown modifier '%s'; expected one of default, lower, lpad, max, pad, upper", modifier.Name)
+}
+
+// Formatted applies modifiers to the text of another fragment.
+type Formatted struct {
+	fragment  Fragment
+	modifiers []*ModifierStatement
+}
+
+// NewFormatted returns Formatted.
+func NewFormatted(fragment Fragment, modifiers []*ModifierStatement) Fragment {
+	return &Formatted{fragment, modifiers}
+}
+
+// Text implements Fragment. Modifiers are applied in the order they were given.
+func (w *Formatted) Text() (string, string) {
+	text, style := w.fragment.Text()
+
+	for _, modifier := range w.modifiers {
+		n, _ := strconv.Atoi(modifier.Value)
+		switch modifier.Name {
+		case modifierDefault:
+			if empty(text, style) {
+				text, style = modifier.Value, `topbar`
+			}
+		case modifierLower:
+			text = strings.ToLower(text)
+		case modifierUpper:
+			text = strings.ToUpper(text)
+		case modifierMax:
+			text = string(format.Truncate([]rune(text), n, format.TruncateEnd))
+		case modifierPad:
+			text = string(format.Fit(text, utils.Max(n, len([]rune(text))), format.AlignLeft, format.TruncateCut))
+		case modifierLpad:
+			text = string(format.Fit(text, utils.Max(n, len([]rune(text))), format.AlignRight, format.TruncateCut))
+		}
+	}
+
+	return text, style
+}
+
+// Click implements Clickable if the modified fragment is clickable.
+func (w *Formatted) Click(x, width int) string {
+	if clickable, ok := w.fragment.(Clickable); ok {
+		return clickable.Click(x, width)
+	}
+	return ""
+}
+
+// empty returns true if a fragment has no text, or its value is missing.
+func empty(text, style string) bool {
+	return len(text) == 0 || style == `tagMissing`
+}
@@ -0,0 +1,29 @@
+package topbar
+
+import (
+	"github.com/ambientsound/visp/api"
+)
+
+// Next draws the next track in the playback queue.
+type Next struct {
+	api api.API
+	tag string
+}
+
+// NewNext returns Next. If a tag is given as parameter, only that tag is drawn.
+func NewNext(a api.API, param string) Fragment {
+	return &Next{a, param}
+}
+
+// Text implements Fragment.
+func (w *Next) Text() (string, string) {
+	row := w.api.PlayerStatus().NextRow
+	if row == nil {
+		return ``, `nextSong`
+	}
+	fields := row.Fields()
+	if len(w.tag) > 0 {
+		return fields[w.tag], `nextSong`
+	}
+	return fields["artist"] + " - " + fields["title"], `nextSong`
+}
@@ -3,6 +3,7 @@ package topbar
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/ambientsound/visp/input/lexer"
 	"github.com/ambientsound/visp/parser"
@@ -28,8 +29,36 @@ type FragmentStatement struct {
 	Variable string
 	Param    string
 	Instance Fragment
+
+	// Modifiers transform the text of a variable, e.g.:
+	//
+	// ${variable|param|default=none|max=20}
+	Modifiers []*ModifierStatement
+
+	// Condition is set on conditional statements, and the fragments in Then are
+	// drawn if the condition has any text, or the fragments in Else otherwise, e.g.:
+	//
+	// ${if variable|param}frag1${else}frag2${end}
+	Condition *FragmentStatement
+	Then      []*FragmentStatement
+	Else      []*FragmentStatement
 }
 
+// ModifierStatement holds information about a modifier, e.g.:
+//
+// max=20
+type ModifierStatement struct {
+	Name  string
+	Value string
+}
+
+// Keywords that delimit conditional statements. They cannot be used as variable names.
+const (
+	keywordIf   = "if"
+	keywordElse = "else"
+	keywordEnd  = "end"
+)
+
 // PieceStatement holds information about a piece, e.g.:
 //
 // ${variable|param} frag2
@@ -93,40 +122,144 @@ func (p *Parser) ParseFragment() (*FragmentStatement, error) {
 	if tok != lexer.TokenIdentifier {
 		return nil, fmt.Errorf("Unexpected %v, expected identifier", lit)
 	}
+
+	if lit == keywordIf {
+		return p.parseConditional(stmt)
+	}
+
 	stmt.Variable = lit
+	err := p.parseParams(stmt)
+	if err != nil {
+		return nil, err
+	}
 
-	// Next, we can either have a separator in order to pass parameters, or
-	// close with a curly bracket.
-	tok, lit = p.ScanIgnoreWhitespace()
+	return stmt, nil
+}
 
-	switch tok {
-	// Parameterized variable, e.g. '${tag|artist}'.
-	case lexer.TokenSeparator:
-		break
+// parseParams parses the parameter and modifiers of a variable, up to and
+// including the closing curly bracket.
+func (p *Parser) parseParams(stmt *FragmentStatement) error {
+	for {
+		// Next, we can either have a separator in order to pass parameters, or
+		// close with a curly bracket.
+		tok, lit := p.ScanIgnoreWhitespace()
 
-	// Finished parsing the variable.
-	case lexer.TokenClose:
-		return stmt, nil
+		switch tok {
+		// Parameterized variable, e.g. '${tag|artist}'.
+		case lexer.TokenSeparator:
+			break
 
-	// No other tokens are valid.
-	default:
-		return nil, fmt.Errorf("Unexpected %v, expected '|' or '}'", lit)
+		// Finished parsing the variable.
+		case lexer.TokenClose:
+			return nil
+
+		// No other tokens are valid.
+		default:
+			return fmt.Errorf("Unexpected %v, expected '|' or '}'", lit)
+		}
+
+		param, err := p.parseParam(stmt.Variable)
+		if err != nil {
+			return err
+		}
+
+		// The first parameter is passed to the variable, unless it is a modifier.
+		modifier, isModifier := parseModifier(param)
+		if !isModifier && len(stmt.Param) == 0 && len(stmt.Modifiers) == 0 {
+			stmt.Param = param
+			continue
+		}
+
+		err = validateModifier(modifier)
+		if err != nil {
+			return err
+		}
+		stmt.Modifiers = append(stmt.Modifiers, modifier)
 	}
+}
 
-	// The only valid token is the parameter.
-	tok, lit = p.ScanIgnoreWhitespace()
-	if tok != lexer.TokenIdentifier {
-		return nil, fmt.Errorf("Unexpected %v, expected parameter to $%s", lit, stmt.Variable)
+// parseParam scans a single parameter, which ends at the next separator or curly bracket.
+// Parameters may contain whitespace, but whitespace at the start or end is ignored.
+func (p *Parser) parseParam(variable string) (string, error) {
+	param := ""
+
+	for {
+		tok, lit := p.Scan()
+
+		switch tok {
+		case lexer.TokenSeparator, lexer.TokenClose:
+			p.Unscan()
+			param = strings.TrimSpace(param)
+			if len(param) == 0 {
+				return "", fmt.Errorf("Unexpected %v, expected parameter to $%s", lit, variable)
+			}
+			return param, nil
+		case lexer.TokenEnd:
+			return "", fmt.Errorf("Unexpected END, expected parameter to $%s", variable)
+		default:
+			param += lit
+		}
 	}
-	stmt.Param = lit
+}
 
-	// The only valid token is the closing curly bracket.
-	tok, lit = p.ScanIgnoreWhitespace()
-	if tok != lexer.TokenClose {
-		return nil, fmt.Errorf("Unexpected %v, expected '}'", lit)
+// parseConditional parses the rest of a conditional statement,
+// after the opening `${if` has been scanned.
+func (p *Parser) parseConditional(stmt *FragmentStatement) (*FragmentStatement, error) {
+	tok, lit := p.ScanIgnoreWhitespace()
+	if tok != lexer.TokenIdentifier || isKeyword(lit) {
+		return nil, fmt.Errorf("Unexpected %v, expected condition", lit)
 	}
 
-	return stmt, nil
+	stmt.Condition = &FragmentStatement{Variable: lit}
+	err := p.parseParams(stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	branch := &stmt.Then
+
+	for {
+		tok, lit := p.Scan()
+
+		switch tok {
+		case lexer.TokenSeparator, lexer.TokenStop, lexer.TokenEnd:
+			return nil, fmt.Errorf("Unexpected %v, expected ${end}", tokenString(tok, lit))
+		}
+
+		p.Unscan()
+		frag, err := p.ParseFragment()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case frag.Variable == keywordElse && branch == &stmt.Then:
+			branch = &stmt.Else
+		case frag.Variable == keywordEnd:
+			return stmt, nil
+		case frag.Variable == keywordElse:
+			return nil, fmt.Errorf("Unexpected ${else}, expected ${end}")
+		default:
+			*branch = append(*branch, frag)
+		}
+	}
+}
+
+// isKeyword returns true if name is reserved for conditional statements.
+func isKeyword(name string) bool {
+	switch name {
+	case keywordIf, keywordElse, keywordEnd:
+		return true
+	}
+	return false
+}
+
+// tokenString returns a readable representation of a token, for use in error messages.
+func tokenString(tok int, lit string) string {
+	if tok == lexer.TokenEnd {
+		return "END"
+	}
+	return fmt.Sprintf("'%s'", lit)
 }
 
 // ParsePiece parses a piece statement.
@@ -0,0 +1,58 @@
+package topbar
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ambientsound/visp/api"
+)
+
+const (
+	defaultProgressWidth = 20
+	progressElapsed      = "━"
+	progressRemaining    = "─"
+)
+
+// Progress draws a bar showing how much of the current song has been played.
+type Progress struct {
+	api   api.API
+	width int
+}
+
+// NewProgress returns Progress. The parameter sets the width of the bar.
+func NewProgress(a api.API, param string) Fragment {
+	width, err := strconv.Atoi(param)
+	if err != nil || width <= 0 {
+		width = defaultProgressWidth
+	}
+	return &Progress{a, width}
+}
+
+// Text implements Fragment.
+func (w *Progress) Text() (string, string) {
+	segments := w.Segments()
+	return segments[0].Text + segments[1].Text, segments[0].Style
+}
+
+// Segments implements Segmented.
+func (w *Progress) Segments() []Segment {
+	playerStatus := w.api.PlayerStatus()
+	elapsed := 0
+	if playerStatus.Item != nil {
+		elapsed = int(playerStatus.ProgressPercentage * float64(w.width))
+	}
+	if elapsed > w.width {
+		elapsed = w.width
+	} else if elapsed < 0 {
+		elapsed = 0
+	}
+	return []Segment{
+		{strings.Repeat(progressElapsed, elapsed), `progressElapsed`},
+		{strings.Repeat(progressRemaining, w.width-elapsed), `progressRemaining`},
+	}
+}
+
+// Click implements Clickable by seeking to the clicked position.
+func (w *Progress) Click(x, width int) string {
+	return seekCommand(w.api, x, width)
+}
@@ -0,0 +1,24 @@
+package topbar
+
+import (
+	"github.com/ambientsound/visp/api"
+)
+
+// Readout draws the cursor position and scroll position of the current list.
+type Readout struct {
+	api api.API
+}
+
+// NewReadout returns Readout.
+func NewReadout(a api.API, param string) Fragment {
+	return &Readout{a}
+}
+
+// Text implements Fragment.
+func (w *Readout) Text() (string, string) {
+	table := w.api.UI().TableWidget()
+	if table == nil || table.List() == nil {
+		return ``, `readout`
+	}
+	return table.PositionReadout(), `readout`
+}
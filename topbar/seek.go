@@ -0,0 +1,18 @@
+package topbar
+
+import (
+	"fmt"
+
+	"github.com/ambientsound/visp/api"
+)
+
+// seekCommand returns a command that seeks to the position in the current track
+// corresponding to a click at x in a fragment that is width characters wide.
+func seekCommand(a api.API, x, width int) string {
+	playerStatus := a.PlayerStatus()
+	if playerStatus.Item == nil || width <= 0 {
+		return ""
+	}
+	fraction := float64(x) / float64(width)
+	return fmt.Sprintf("seek %d", int(fraction*float64(playerStatus.Item.Duration)/1000))
+}
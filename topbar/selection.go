@@ -0,0 +1,78 @@
+package topbar
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/pkg/format"
+)
+
+// Selection draws information about the selected rows in the current list.
+// Nothing is drawn if no rows are selected.
+type Selection struct {
+	api api.API
+	f   func() (string, string)
+}
+
+// NewSelection returns Selection.
+func NewSelection(a api.API, param string) Fragment {
+	selection := &Selection{a, nil}
+	switch param {
+	case `time`:
+		selection.f = selection.textTime
+	default:
+		selection.f = selection.textCount
+	}
+	return selection
+}
+
+// Text implements Fragment.
+func (w *Selection) Text() (string, string) {
+	return w.f()
+}
+
+func (w *Selection) textCount() (string, string) {
+	lst := w.api.List()
+	if lst == nil {
+		return ``, `selectionSummary`
+	}
+	count := len(selected(lst))
+	if count == 0 {
+		return ``, `selectionSummary`
+	}
+	return fmt.Sprintf("%d", count), `selectionSummary`
+}
+
+// textTime returns the total length of all selected tracks.
+func (w *Selection) textTime() (string, string) {
+	lst := w.api.List()
+	if lst == nil {
+		return ``, `selectionSummary`
+	}
+	indices := selected(lst)
+	if len(indices) == 0 {
+		return ``, `selectionSummary`
+	}
+	total := 0
+	for _, i := range indices {
+		secs, ok := format.ParseSeconds(lst.Row(i).Fields()["time"])
+		if ok {
+			total += secs
+		}
+	}
+	return format.Duration(strconv.Itoa(total)), `selectionSummary`
+}
+
+// selected returns the indices of all selected rows. Unlike list.SelectionIndices,
+// the cursor row is not returned if nothing is selected.
+func selected(lst list.List) []int {
+	indices := make([]int, 0)
+	for i := 0; i < lst.Len(); i++ {
+		if lst.Selected(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
@@ -19,3 +19,8 @@ func (w *Time) Text() (string, string) {
 	playerStatus := w.api.PlayerStatus()
 	return playerStatus.TrackRow.Fields()["time"], `time`
 }
+
+// Click implements Clickable by seeking to the clicked position.
+func (w *Time) Click(x, width int) string {
+	return seekCommand(w.api, x, width)
+}
@@ -14,15 +14,40 @@ type Fragment interface {
 	Text() (string, string)
 }
 
+// Clickable is implemented by fragments that react to mouse clicks.
+type Clickable interface {
+	// Click returns a command that should be run when the fragment is clicked,
+	// or an empty string if nothing should happen. The click position x ranges
+	// from zero to the width of the drawn text.
+	Click(x, width int) string
+}
+
+// Segment is a part of a fragment that is drawn with its own style.
+type Segment struct {
+	Text  string
+	Style string
+}
+
+// Segmented is implemented by fragments that are drawn using more than one style.
+// The text of all segments put together must be the same as the text returned by Text.
+type Segmented interface {
+	Segments() []Segment
+}
+
 // fragments is a map of fragments that can be drawn in the topbar, along with
 // their textual representation. When implementing a new topbar fragment, place
 // its constructor in this map.
 var fragments = map[string]func(api.API, string) Fragment{
 	"device":    NewDevice,
+	"context":   NewContext,
 	"elapsed":   NewElapsed,
 	"liked":     NewLiked,
 	"list":      NewList,
 	"mode":      NewMode,
+	"next":      NewNext,
+	"progress":  NewProgress,
+	"readout":   NewReadout,
+	"selection": NewSelection,
 	"shortname": NewShortname,
 	"state":     NewState,
 	"tag":       NewTag,
@@ -33,14 +58,61 @@ var fragments = map[string]func(api.API, string) Fragment{
 }
 
 // NewFragment constructs a new Fragment based on a parsed topbar fragment statement.
+// Fragments of conditional statements are instantiated as well.
 func NewFragment(a api.API, stmt *FragmentStatement) (Fragment, error) {
+	if stmt.Condition != nil {
+		return newConditional(a, stmt)
+	}
 	if len(stmt.Variable) == 0 {
 		return NewText(stmt.Literal), nil
 	}
-	if ctor, ok := fragments[stmt.Variable]; ok {
-		return ctor(a, stmt.Param), nil
+	if isKeyword(stmt.Variable) {
+		return nil, fmt.Errorf("Unexpected '${%s}' outside of '${if}'", stmt.Variable)
+	}
+	ctor, ok := fragments[stmt.Variable]
+	if !ok {
+		return nil, fmt.Errorf("Unrecognized variable '${%s}'", stmt.Variable)
+	}
+	frag := ctor(a, stmt.Param)
+	if len(stmt.Modifiers) > 0 {
+		frag = NewFormatted(frag, stmt.Modifiers)
+	}
+	return frag, nil
+}
+
+// newConditional instantiates the condition and fragments of a conditional statement.
+func newConditional(a api.API, stmt *FragmentStatement) (Fragment, error) {
+	condition, err := NewFragment(a, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Condition.Instance = condition
+
+	then, err := newFragments(a, stmt.Then)
+	if err != nil {
+		return nil, err
+	}
+
+	otherwise, err := newFragments(a, stmt.Else)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConditional(condition, then, otherwise), nil
+}
+
+// newFragments instantiates a list of fragment statements.
+func newFragments(a api.API, stmts []*FragmentStatement) ([]Fragment, error) {
+	frags := make([]Fragment, len(stmts))
+	for i, stmt := range stmts {
+		frag, err := NewFragment(a, stmt)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Instance = frag
+		frags[i] = frag
 	}
-	return nil, fmt.Errorf("Unrecognized variable '${%s}'", stmt.Variable)
+	return frags, nil
 }
 
 // Parse sets up a lexer and parser for a topbar matrix statement, instantiates
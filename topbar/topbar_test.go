@@ -14,14 +14,25 @@ var fragmentTests = []struct {
 	statement topbar.FragmentStatement
 }{
 	// Valid forms
-	{`plain`, true, topbar.FragmentStatement{`plain`, ``, ``, nil}},
-	{`plain; and more`, true, topbar.FragmentStatement{`plain`, ``, ``, nil}},
-	{`     |    `, true, topbar.FragmentStatement{`     `, ``, ``, nil}},
-	{`foo;bar`, true, topbar.FragmentStatement{`foo`, ``, ``, nil}},
-	{`$var`, true, topbar.FragmentStatement{``, `var`, ``, nil}},
-	{`${var}`, true, topbar.FragmentStatement{``, `var`, ``, nil}},
-	{`${var|param}`, true, topbar.FragmentStatement{``, `var`, `param`, nil}},
-	{`${  var  |  param  }`, true, topbar.FragmentStatement{``, `var`, `param`, nil}},
+	{`plain`, true, topbar.FragmentStatement{Literal: `plain`, Variable: ``, Param: ``}},
+	{`plain; and more`, true, topbar.FragmentStatement{Literal: `plain`, Variable: ``, Param: ``}},
+	{`     |    `, true, topbar.FragmentStatement{Literal: `     `, Variable: ``, Param: ``}},
+	{`foo;bar`, true, topbar.FragmentStatement{Literal: `foo`, Variable: ``, Param: ``}},
+	{`$var`, true, topbar.FragmentStatement{Literal: ``, Variable: `var`, Param: ``}},
+	{`${var}`, true, topbar.FragmentStatement{Literal: ``, Variable: `var`, Param: ``}},
+	{`${var|param}`, true, topbar.FragmentStatement{Literal: ``, Variable: `var`, Param: `param`}},
+	{`${  var  |  param  }`, true, topbar.FragmentStatement{Literal: ``, Variable: `var`, Param: `param`}},
+	{`${var|param|upper}`, true, topbar.FragmentStatement{Variable: `var`, Param: `param`, Modifiers: []*topbar.ModifierStatement{{Name: `upper`}}}},
+	{`${var|max=5|default=not playing}`, true, topbar.FragmentStatement{Variable: `var`, Modifiers: []*topbar.ModifierStatement{{Name: `max`, Value: `5`}, {Name: `default`, Value: `not playing`}}}},
+	{`${if var|param}yes${else}no${end}`, true, topbar.FragmentStatement{
+		Condition: &topbar.FragmentStatement{Variable: `var`, Param: `param`},
+		Then:      []*topbar.FragmentStatement{{Literal: `yes`}},
+		Else:      []*topbar.FragmentStatement{{Literal: `no`}},
+	}},
+	{`${if var}${var}${end}`, true, topbar.FragmentStatement{
+		Condition: &topbar.FragmentStatement{Variable: `var`},
+		Then:      []*topbar.FragmentStatement{{Variable: `var`}},
+	}},
 
 	// Invalid forms
 	{`${var`, false, topbar.FragmentStatement{}},
@@ -33,6 +44,13 @@ var fragmentTests = []struct {
 	{`${{`, false, topbar.FragmentStatement{}},
 	{`${$`, false, topbar.FragmentStatement{}},
 	{`${   }`, false, topbar.FragmentStatement{}},
+	{`${var|param|max=foo}`, false, topbar.FragmentStatement{}},
+	{`${var|param|nonexist=1}`, false, topbar.FragmentStatement{}},
+	{`${var|upper=1}`, false, topbar.FragmentStatement{}},
+	{`${if}`, false, topbar.FragmentStatement{}},
+	{`${if var}yes`, false, topbar.FragmentStatement{}},
+	{`${if var}yes|no${end}`, false, topbar.FragmentStatement{}},
+	{`${if var}a${else}b${else}c${end}`, false, topbar.FragmentStatement{}},
 }
 
 func TestFragments(t *testing.T) {
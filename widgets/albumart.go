@@ -0,0 +1,170 @@
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/albumart"
+	"github.com/ambientsound/visp/style"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+)
+
+// AlbumArt is a widget that shows the cover image of the currently playing album,
+// to the right of the panes.
+//
+// With the half-block protocol, the image is drawn as regular text. Otherwise, the widget
+// is left blank, and the image is written directly to the terminal by Flush.
+type AlbumArt struct {
+	api   api.API
+	image image.Image
+
+	// scaled is a copy of the image that fits the widget when drawn with half blocks.
+	scaled       *image.RGBA
+	scaledWidth  int
+	scaledHeight int
+
+	// drawn describes the image last written by Flush, and kitty is true if it may still be visible.
+	drawn string
+	kitty bool
+
+	view views.View
+	style.Styled
+	views.WidgetWatchers
+}
+
+var _ views.Widget = &AlbumArt{}
+
+// NewAlbumArt returns an album art widget without any image.
+func NewAlbumArt(a api.API) *AlbumArt {
+	return &AlbumArt{
+		api: a,
+	}
+}
+
+// SetImage replaces the image shown. A nil image clears the widget.
+func (w *AlbumArt) SetImage(img image.Image) {
+	w.image = img
+	w.scaled = nil
+}
+
+// Protocol returns the protocol used to draw the image.
+func (w *AlbumArt) Protocol() string {
+	protocol := options.GetString(options.AlbumArtProtocol)
+	switch protocol {
+	case albumart.Kitty, albumart.Sixel, albumart.HalfBlock:
+		return protocol
+	default:
+		return albumart.Detect()
+	}
+}
+
+// Draw blanks the widget, and draws the image if using half blocks.
+func (w *AlbumArt) Draw() {
+	w.SetStylesheet(w.api.Styles())
+	w.view.Fill(' ', w.Style("default"))
+
+	if w.image == nil || w.Protocol() != albumart.HalfBlock {
+		return
+	}
+
+	// Each cell shows two pixels on top of each other; the upper half block
+	// is drawn in the foreground color, and the lower in the background color.
+	cols, rows := w.view.Size()
+	img := w.scale(cols, rows*2)
+	bounds := img.Bounds()
+	offset := (cols - bounds.Dx()) / 2
+
+	for y := 0; y*2 < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			st := tcell.StyleDefault.Foreground(rgb(img, x, y*2))
+			if y*2+1 < bounds.Dy() {
+				st = st.Background(rgb(img, x, y*2+1))
+			}
+			w.view.SetContent(x+offset, y, '▀', nil, st)
+		}
+	}
+}
+
+// scale returns the image scaled to fit within width x height pixels.
+func (w *AlbumArt) scale(width, height int) *image.RGBA {
+	if w.scaled == nil || w.scaledWidth != width || w.scaledHeight != height {
+		w.scaled = albumart.Fit(w.image, width, height)
+		w.scaledWidth = width
+		w.scaledHeight = height
+	}
+	return w.scaled
+}
+
+func rgb(img *image.RGBA, x, y int) tcell.Color {
+	c := img.RGBAAt(x, y)
+	return tcell.NewRGBColor(int32(c.R), int32(c.G), int32(c.B))
+}
+
+// Flush writes the image to the terminal using the kitty or sixel protocol, at the screen position x, y.
+// Nothing is written unless the image, protocol, or position has changed since the last call,
+// because the terminal keeps showing the image as long as the cells beneath it are not redrawn.
+func (w *AlbumArt) Flush(out io.Writer, x, y int) {
+	cols, rows := w.view.Size()
+	protocol := w.Protocol()
+
+	drawn := ""
+	if w.image != nil && cols > 0 && rows > 0 && protocol != albumart.HalfBlock {
+		drawn = fmt.Sprintf("%p %s %d %d %d %d", w.image, protocol, x, y, cols, rows)
+	}
+	if drawn == w.drawn {
+		return
+	}
+	w.drawn = drawn
+
+	if w.kitty {
+		_ = albumart.ClearKitty(out)
+		w.kitty = false
+	}
+	if len(drawn) == 0 {
+		return
+	}
+
+	cellWidth, cellHeight := albumart.CellSize()
+	img := albumart.Fit(w.image, cols*cellWidth, rows*cellHeight)
+	imgCols := (img.Bounds().Dx() + cellWidth - 1) / cellWidth
+	imgRows := (img.Bounds().Dy() + cellHeight - 1) / cellHeight
+
+	// Save the cursor position, draw the image, and restore the cursor position.
+	_, _ = io.WriteString(out, "\x1b7"+albumart.MoveTo(x+(cols-imgCols)/2, y))
+	switch protocol {
+	case albumart.Kitty:
+		_ = albumart.EncodeKitty(out, img, imgCols, imgRows)
+		w.kitty = true
+	case albumart.Sixel:
+		_ = albumart.EncodeSixel(out, img)
+	}
+	_, _ = io.WriteString(out, "\x1b8")
+}
+
+// Invalidate makes the next call to Flush write the image again, after the screen has been cleared.
+func (w *AlbumArt) Invalidate() {
+	w.drawn = ""
+}
+
+func (w *AlbumArt) HandleEvent(ev tcell.Event) bool {
+	return false
+}
+
+// Size returns the configured width of the widget, or zero if album art is disabled.
+func (w *AlbumArt) Size() (int, int) {
+	if !options.GetBool(options.AlbumArt) {
+		return 0, 0
+	}
+	return options.GetInt(options.AlbumArtWidth), 0
+}
+
+func (w *AlbumArt) Resize() {
+}
+
+func (w *AlbumArt) SetView(v views.View) {
+	w.view = v
+}
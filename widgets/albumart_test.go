@@ -0,0 +1,88 @@
+package widgets_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/albumart"
+	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/widgets"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlbumArt(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(4, 2)
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(style.Stylesheet{})
+
+	options.Set(options.AlbumArt, true)
+	options.Set(options.AlbumArtWidth, 4)
+	options.Set(options.AlbumArtProtocol, albumart.HalfBlock)
+	defer options.Set(options.AlbumArt, false)
+	defer options.Set(options.AlbumArtProtocol, albumart.Auto)
+
+	art := widgets.NewAlbumArt(a)
+	art.SetView(views.NewViewPort(screen, 0, 0, 4, 2))
+
+	width, _ := art.Size()
+	assert.Equal(t, 4, width)
+
+	// Top half red, bottom half blue.
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if y < 2 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	art.SetImage(img)
+	art.Draw()
+	screen.Show()
+
+	r, _, st, _ := screen.GetContent(0, 0)
+	fg, bg, _ := st.Decompose()
+	assert.Equal(t, '▀', r)
+	assert.Equal(t, tcell.NewRGBColor(255, 0, 0), fg)
+	assert.Equal(t, tcell.NewRGBColor(255, 0, 0), bg)
+
+	_, _, st, _ = screen.GetContent(3, 1)
+	fg, bg, _ = st.Decompose()
+	assert.Equal(t, tcell.NewRGBColor(0, 0, 255), fg)
+	assert.Equal(t, tcell.NewRGBColor(0, 0, 255), bg)
+
+	// Half blocks are not written directly to the terminal.
+	out := &bytes.Buffer{}
+	art.Flush(out, 0, 0)
+	assert.Equal(t, 0, out.Len())
+
+	// Kitty images are written once, and removed when the image is cleared.
+	options.Set(options.AlbumArtProtocol, albumart.Kitty)
+	art.Flush(out, 0, 0)
+	assert.Contains(t, out.String(), "\x1b_Ga=T")
+
+	out.Reset()
+	art.Flush(out, 0, 0)
+	assert.Equal(t, 0, out.Len())
+
+	art.SetImage(nil)
+	art.Flush(out, 0, 0)
+	assert.Equal(t, "\x1b_Ga=d,q=2\x1b\\", out.String())
+
+	options.Set(options.AlbumArt, false)
+	width, _ = art.Size()
+	assert.Equal(t, 0, width)
+}
@@ -1,9 +1,13 @@
 package widgets
 
 import (
-	"github.com/ambientsound/visp/list"
 	"strings"
 	"unicode"
+
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/format"
 )
 
 type column struct {
@@ -12,6 +16,56 @@ type column struct {
 	title        string
 	rightPadding int
 	width        int
+
+	// Display settings from the `column.<tag>.*` options.
+	align      string
+	format     format.Formatter
+	formatted  bool
+	truncate   string
+	fixedWidth int
+}
+
+// configure reads the display settings for the column from the options.
+// A fixed width given as a percentage is calculated from totalWidth.
+func (c *column) configure(totalWidth int) {
+	var err error
+
+	c.align = options.GetString(options.ColumnOption(c.key, options.ColumnAlign))
+	c.truncate = options.GetString(options.ColumnOption(c.key, options.ColumnTruncate))
+
+	name := options.GetString(options.ColumnOption(c.key, options.ColumnFormat))
+	c.format, err = format.Get(name)
+	c.formatted = err == nil && len(name) > 0 && name != "raw"
+	if err != nil {
+		log.Errorf("column %s: %s", c.key, err)
+		c.format = format.Raw
+	}
+
+	c.fixedWidth, err = format.Width(options.GetString(options.ColumnOption(c.key, options.ColumnWidth)), totalWidth)
+	if err != nil {
+		log.Errorf("column %s: %s", c.key, err)
+	}
+}
+
+// measure returns the sizes of the column's values as they are drawn.
+// Formatted values may be wider or narrower than the values stored in the list,
+// so they are measured again.
+func (c *column) measure(lst list.List, col list.Column) list.Column {
+	if !c.formatted {
+		return col
+	}
+	measured := list.Column{}
+	for i := 0; i < lst.Len(); i++ {
+		if value, ok := lst.Row(i).Fields()[c.key]; ok {
+			measured.Add(c.format(value))
+		}
+	}
+	return measured
+}
+
+// text returns the formatted value of a field, fitted to the column width.
+func (c *column) text(value string) []rune {
+	return format.Fit(c.format(value), c.width-c.rightPadding, c.align, c.truncate)
 }
 
 func ColumnTitle(key string) string {
@@ -1,9 +1,13 @@
 package widgets
 
 import (
+	"os"
+	"unicode/utf8"
+
 	"github.com/ambientsound/visp/api"
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/multibar"
+	"github.com/ambientsound/visp/options"
 	"github.com/ambientsound/visp/style"
 	"github.com/gdamore/tcell/v2"
 	"github.com/gdamore/tcell/v2/views"
@@ -12,15 +16,21 @@ import (
 type widgets struct {
 	layout   *views.BoxLayout
 	Topbar   *Topbar
-	multibar *Multibar
-	table    *Table
+	tabbar   *Tabbar
+	Multibar *Multibar
+	panes    *Panes
+	Info     *InfoPanel
+	AlbumArt *AlbumArt
+	whichKey *WhichKey
 }
 
 type Application struct {
-	api     api.API
-	events  chan tcell.Event
-	screen  tcell.Screen
-	Widgets widgets
+	api      api.API
+	commands chan string
+	events   chan tcell.Event
+	mouse    mouseState
+	screen   tcell.Screen
+	Widgets  widgets
 	style.Styled
 }
 
@@ -39,28 +49,40 @@ func NewApplication(a api.API) (*Application, error) {
 		return nil, err
 	}
 
+	// Pasted text is recognized by the multibar, and not interpreted as key bindings.
+	screen.EnablePaste()
 	screen.Clear()
 	screen.Show()
 
 	return &Application{
-		api:    a,
-		events: make(chan tcell.Event, 1024),
-		screen: screen,
+		api:      a,
+		commands: make(chan string, 16),
+		events:   make(chan tcell.Event, 1024),
+		screen:   screen,
 	}, nil
 }
 
 func (app *Application) Init() {
 	app.Widgets.Topbar = NewTopbar(app.api)
-	app.Widgets.table = NewTable(app.api)
-	app.Widgets.multibar = NewMultibarWidget(app.api)
+	app.Widgets.tabbar = NewTabbar(app.api)
+	app.Widgets.panes = NewPanes(app.api)
+	app.Widgets.Info = NewInfoPanel(app.api)
+	app.Widgets.AlbumArt = NewAlbumArt(app.api)
+	app.Widgets.Multibar = NewMultibarWidget(app.api)
+	app.Widgets.whichKey = &WhichKey{}
 	app.Resize()
 }
 
 func (app *Application) Resize() {
 	app.Widgets.layout = views.NewBoxLayout(views.Vertical)
 	app.Widgets.layout.AddWidget(app.Widgets.Topbar, 0)
-	app.Widgets.layout.AddWidget(app.Widgets.table, 1)
-	app.Widgets.layout.AddWidget(app.Widgets.multibar, 0)
+	app.Widgets.layout.AddWidget(app.Widgets.tabbar, 0)
+	main := views.NewBoxLayout(views.Horizontal)
+	main.AddWidget(app.Widgets.panes, 1)
+	main.AddWidget(app.Widgets.Info, 0)
+	main.AddWidget(app.Widgets.AlbumArt, 0)
+	app.Widgets.layout.AddWidget(main, 1)
+	app.Widgets.layout.AddWidget(app.Widgets.Multibar, 0)
 	app.Widgets.layout.SetView(app.screen)
 }
 
@@ -70,10 +92,16 @@ func (app *Application) HandleEvent(ev tcell.Event) bool {
 		cols, rows := e.Size()
 		log.Debugf("Terminal resize: %dx%d", cols, rows)
 		app.screen.Sync()
+		app.Widgets.AlbumArt.Invalidate()
 		app.Resize()
 		return true
 	case *tcell.EventKey:
 		return false
+	case *tcell.EventMouse:
+		if options.GetBool(options.Mouse) {
+			app.handleMouse(e)
+		}
+		return true
 	default:
 		app.Widgets.layout.HandleEvent(ev)
 		return false
@@ -82,8 +110,21 @@ func (app *Application) HandleEvent(ev tcell.Event) bool {
 
 func (app *Application) Draw() {
 	app.Widgets.layout.Draw()
+	app.Widgets.whichKey.SetStylesheet(app.api.Styles())
+	_, multibarHeight := app.Widgets.Multibar.Size()
+	app.Widgets.whichKey.Draw(app.screen, multibarHeight)
 	app.updateCursor()
 	app.screen.Show()
+	app.flushAlbumArt()
+}
+
+// flushAlbumArt writes album art directly to the terminal, after the screen has been drawn.
+func (app *Application) flushAlbumArt() {
+	width, _ := app.screen.Size()
+	artWidth, _ := app.Widgets.AlbumArt.view.Size()
+	_, topbarHeight := app.Widgets.Topbar.Size()
+	_, tabbarHeight := app.Widgets.tabbar.Size()
+	app.Widgets.AlbumArt.Flush(os.Stdout, width-artWidth, topbarHeight+tabbarHeight)
 }
 
 func (app *Application) Poll() {
@@ -96,23 +137,53 @@ func (app *Application) Events() <-chan tcell.Event {
 	return app.events
 }
 
+// Commands returns a channel sending any commands triggered by the mouse.
+func (app *Application) Commands() <-chan string {
+	return app.commands
+}
+
 func (app *Application) Finish() {
 	app.screen.Fini()
 }
 
+// Suspend gives the terminal back to other programs, until Resume is called.
+func (app *Application) Suspend() error {
+	return app.screen.Suspend()
+}
+
+// Resume takes over the terminal after Suspend, and redraws the screen.
+func (app *Application) Resume() error {
+	err := app.screen.Resume()
+	if err != nil {
+		return err
+	}
+	app.Refresh()
+	return nil
+}
+
 func (app *Application) Refresh() {
 	app.screen.Sync()
+	app.Widgets.AlbumArt.Invalidate()
+}
+
+// WhichKey returns the popup showing pending key bindings.
+func (app *Application) WhichKey() *WhichKey {
+	return app.Widgets.whichKey
+}
+
+func (app *Application) Panes() api.Panes {
+	return app.Widgets.panes
 }
 
 func (app *Application) TableWidget() api.TableWidget {
-	return app.Widgets.table
+	return app.Widgets.panes.Focused()
 }
 
 func (app *Application) updateCursor() {
 	switch app.api.Multibar().Mode() {
 	case multibar.ModeInput, multibar.ModeSearch:
 		_, ymax := app.screen.Size()
-		x := app.api.Multibar().Cursor() + 1
+		x := utf8.RuneCountInString(app.api.Multibar().Prompt()) + app.api.Multibar().Cursor()
 		app.screen.ShowCursor(x, ymax-1)
 	default:
 		app.screen.HideCursor()
@@ -0,0 +1,38 @@
+package widgets
+
+import (
+	"github.com/ambientsound/visp/api"
+	"github.com/gdamore/tcell/v2/views"
+)
+
+// Size of the imaginary screen used in batch mode.
+const (
+	headlessWidth  = 200
+	headlessHeight = 50
+)
+
+// Headless is a user interface without a terminal, used when running in batch mode.
+// Commands can manipulate the table widgets as usual, but nothing is drawn.
+type Headless struct {
+	panes *Panes
+}
+
+var _ api.UI = &Headless{}
+
+func NewHeadless(a api.API) *Headless {
+	panes := NewPanes(a)
+	panes.SetView(views.NewViewPort(nil, 0, 0, headlessWidth, headlessHeight))
+	return &Headless{
+		panes: panes,
+	}
+}
+
+func (h *Headless) Refresh() {}
+
+func (h *Headless) Panes() api.Panes {
+	return h.panes
+}
+
+func (h *Headless) TableWidget() api.TableWidget {
+	return h.panes.Focused()
+}
@@ -0,0 +1,170 @@
+package widgets
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/spotify/info"
+	"github.com/ambientsound/visp/style"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+)
+
+// maxExtras is the number of rows to remember extra information about.
+const maxExtras = 500
+
+// InfoPanel is a widget that shows all fields of the track, album or playlist under the cursor,
+// to the right of the panes. Long values are wrapped over several lines.
+//
+// Information that is not part of the row itself is looked up by the program,
+// and given to the panel with SetExtras.
+type InfoPanel struct {
+	api api.API
+
+	// extras holds more information about rows, by row ID. A nil map means that the lookup is in progress.
+	extras map[string]map[string]string
+
+	view views.View
+	style.Styled
+	views.WidgetWatchers
+}
+
+var _ views.Widget = &InfoPanel{}
+
+// NewInfoPanel returns an empty info panel.
+func NewInfoPanel(a api.API) *InfoPanel {
+	return &InfoPanel{
+		api:    a,
+		extras: make(map[string]map[string]string),
+	}
+}
+
+// Row returns the row shown in the panel, or nil if there is nothing to show.
+func (w *InfoPanel) Row() list.Row {
+	if !options.GetBool(options.InfoPanel) || w.api.List() == nil {
+		return nil
+	}
+	row := w.api.List().CursorRow()
+	if row == nil {
+		return nil
+	}
+	switch row.Kind() {
+	case list.DataTypeTrack, list.DataTypeAlbum, list.DataTypePlaylist:
+		return row
+	default:
+		return nil
+	}
+}
+
+// Wanted returns the row shown in the panel, if more information about it should be looked up.
+func (w *InfoPanel) Wanted() list.Row {
+	row := w.Row()
+	if row == nil || !spotify_info.Supported(row) {
+		return nil
+	}
+	if _, ok := w.extras[row.ID()]; ok {
+		return nil
+	}
+	return row
+}
+
+// SetExtras stores more information about a row. A nil map marks the lookup as started.
+func (w *InfoPanel) SetExtras(id string, extras map[string]string) {
+	if len(w.extras) >= maxExtras {
+		w.extras = make(map[string]map[string]string)
+	}
+	w.extras[id] = extras
+}
+
+// Draw draws the fields of the row under the cursor, each field name followed by its value.
+func (w *InfoPanel) Draw() {
+	w.SetStylesheet(w.api.Styles())
+	w.view.Fill(' ', w.Style("default"))
+
+	width, height := w.view.Size()
+	for y := 0; y < height; y++ {
+		w.view.SetContent(0, y, '│', nil, w.Style("paneSeparator"))
+	}
+
+	row := w.Row()
+	if row == nil || width < 4 {
+		return
+	}
+
+	fields := make(map[string]string)
+	for key, value := range row.Fields() {
+		fields[key] = value
+	}
+	for key, value := range w.extras[row.ID()] {
+		fields[key] = value
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key, value := range fields {
+		if len(value) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	y := 0
+	for _, key := range keys {
+		drawNext(w.view, 2, y, ColumnTitle(key), w.Style("infoKey"))
+		y++
+		for _, line := range wrap(fields[key], width-4) {
+			drawNext(w.view, 4, y, line, w.Style(key))
+			y++
+		}
+	}
+}
+
+// wrap splits text into lines no longer than width characters, breaking lines between words if possible.
+func wrap(text string, width int) []string {
+	lines := make([]string, 0)
+	line := make([]rune, 0, width)
+
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		if len(line) > 0 && len(line)+1+len(runes) > width {
+			lines = append(lines, string(line))
+			line = line[:0]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		// Words that are too long for a line of their own are split.
+		for len(runes) > width {
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		line = append(line, runes...)
+	}
+
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+
+	return lines
+}
+
+func (w *InfoPanel) HandleEvent(ev tcell.Event) bool {
+	return false
+}
+
+// Size returns the configured width of the panel, or zero if the panel is disabled.
+func (w *InfoPanel) Size() (int, int) {
+	if !options.GetBool(options.InfoPanel) {
+		return 0, 0
+	}
+	return options.GetInt(options.InfoPanelWidth), 0
+}
+
+func (w *InfoPanel) Resize() {
+}
+
+func (w *InfoPanel) SetView(v views.View) {
+	w.view = v
+}
@@ -0,0 +1,77 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/widgets"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoPanel(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(16, 10)
+
+	lst := list.New()
+	lst.Add(list.NewRow("mix", list.DataTypePlaylist, map[string]string{
+		"name":  "Mix",
+		"owner": "",
+	}))
+	lst.Add(list.NewRow("1", list.DataTypeLogLine, map[string]string{"logMessage": "hello"}))
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(style.Stylesheet{})
+	a.On("List").Return(lst)
+
+	options.Set(options.InfoPanel, true)
+	options.Set(options.InfoPanelWidth, 16)
+	defer options.Set(options.InfoPanel, false)
+
+	panel := widgets.NewInfoPanel(a)
+	panel.SetView(views.NewViewPort(screen, 0, 0, 16, 10))
+
+	width, _ := panel.Size()
+	assert.Equal(t, 16, width)
+
+	// Extra information is looked up once.
+	require.NotNil(t, panel.Wanted())
+	assert.Equal(t, "mix", panel.Wanted().ID())
+	panel.SetExtras("mix", nil)
+	assert.Nil(t, panel.Wanted())
+
+	// Fields are sorted, empty fields are hidden, and long values are wrapped.
+	panel.SetExtras("mix", map[string]string{"description": "Songs to code to at night"})
+	panel.Draw()
+	screen.Show()
+
+	lines := make([]string, 0)
+	for y := 0; y < 7; y++ {
+		lines = append(lines, screenLine(screen, y))
+	}
+	assert.Equal(t, []string{
+		"│ Description",
+		"│   Songs to",
+		"│   code to at",
+		"│   night",
+		"│ Name",
+		"│   Mix",
+		"│",
+	}, lines)
+
+	// Rows other than tracks, albums and playlists are not shown.
+	lst.SetCursor(1)
+	assert.Nil(t, panel.Row())
+	assert.Nil(t, panel.Wanted())
+
+	options.Set(options.InfoPanel, false)
+	width, _ = panel.Size()
+	assert.Equal(t, 0, width)
+}
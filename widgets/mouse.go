@@ -0,0 +1,182 @@
+package widgets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ambientsound/visp/commands"
+	"github.com/ambientsound/visp/keysequence"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/multibar"
+	"github.com/ambientsound/visp/topbar"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// doubleClickInterval is the longest time between two clicks on the same row
+	// for them to count as a double click.
+	doubleClickInterval = time.Millisecond * 400
+
+	// wheelScrollRows is the number of rows scrolled for each step of the mouse wheel.
+	wheelScrollRows = 3
+)
+
+// mouseState tracks mouse buttons between events, so that clicks can be detected.
+type mouseState struct {
+	buttons   tcell.ButtonMask
+	lastClick time.Time
+	lastPane  int
+	lastRow   int
+}
+
+// SetMouse enables or disables mouse events.
+func (app *Application) SetMouse(enabled bool) {
+	if enabled {
+		app.screen.EnableMouse()
+	} else {
+		app.screen.DisableMouse()
+	}
+}
+
+// handleMouse dispatches mouse events to the widget under the mouse pointer.
+func (app *Application) handleMouse(ev *tcell.EventMouse) {
+	buttons := ev.Buttons()
+	pressed := buttons &^ app.mouse.buttons
+	app.mouse.buttons = buttons & (tcell.Button1 | tcell.Button2 | tcell.Button3)
+
+	x, y := ev.Position()
+	_, height := app.screen.Size()
+	_, topbarHeight := app.Widgets.Topbar.Size()
+	_, tabbarHeight := app.Widgets.tabbar.Size()
+	_, multibarHeight := app.Widgets.Multibar.Size()
+
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		app.tableAt(x, y-topbarHeight-tabbarHeight).ScrollViewport(-wheelScrollRows, false)
+	case buttons&tcell.WheelDown != 0:
+		app.tableAt(x, y-topbarHeight-tabbarHeight).ScrollViewport(wheelScrollRows, false)
+	case pressed&tcell.Button1 == 0:
+		return
+	case y < topbarHeight:
+		app.clickTopbar(x, y)
+	case y < topbarHeight+tabbarHeight:
+		app.clickTabbar(x)
+	case y >= height-multibarHeight:
+		app.clickMultibar()
+	default:
+		app.clickTable(x, y-topbarHeight-tabbarHeight, ev.Modifiers())
+	}
+}
+
+// tableAt returns the table under the mouse pointer, or the focused table.
+func (app *Application) tableAt(x, y int) *Table {
+	panes := app.Widgets.panes
+	if pane, _, _, ok := panes.PaneAt(x, y); ok {
+		return panes.tables[pane]
+	}
+	return panes.Focused()
+}
+
+// clickTopbar runs the command associated with the clicked topbar fragment, if any.
+func (app *Application) clickTopbar(x, y int) {
+	frag, pos, width := app.Widgets.Topbar.FragmentAt(x, y)
+	clickable, ok := frag.(topbar.Clickable)
+	if !ok {
+		return
+	}
+	app.exec(clickable.Click(pos, width))
+}
+
+// clickTabbar switches to the clicked window.
+func (app *Application) clickTabbar(x int) {
+	if index, ok := app.Widgets.tabbar.TabAt(x); ok {
+		app.exec(fmt.Sprintf("list %d", index+1))
+	}
+}
+
+// clickMultibar switches the multibar to command input mode.
+func (app *Application) clickMultibar() {
+	if app.api.Multibar().Mode() == multibar.ModeNormal {
+		app.api.Multibar().SetMode(multibar.ModeInput)
+	}
+}
+
+// clickTable focuses the clicked pane, sorts by a column when its header is clicked, and moves the
+// cursor when a row is clicked. Shift-click selects all rows between the cursor and the clicked row,
+// and Ctrl-click toggles selection of the clicked row. Double-clicking a row runs the command bound to <Enter>.
+func (app *Application) clickTable(x, y int, mod tcell.ModMask) {
+	panes := app.Widgets.panes
+	pane, x, y, ok := panes.PaneAt(x, y)
+	if !ok {
+		return
+	}
+	if pane != panes.Focus() {
+		_ = panes.SetFocus(pane)
+		app.api.SetList(panes.Focused().List())
+	}
+
+	table := panes.Focused()
+	lst := table.List()
+
+	if y == 0 {
+		if key, ok := table.ColumnAt(x); ok {
+			app.exec(fmt.Sprintf("sort %s", key))
+		}
+		return
+	}
+
+	row, ok := table.RowAt(y)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	double := pane == app.mouse.lastPane && row == app.mouse.lastRow && now.Sub(app.mouse.lastClick) < doubleClickInterval
+	app.mouse.lastPane = pane
+	app.mouse.lastRow = row
+	app.mouse.lastClick = now
+
+	switch {
+	case mod&tcell.ModShift != 0:
+		from, to := lst.Cursor(), row
+		if from > to {
+			from, to = to, from
+		}
+		for i := from; i <= to; i++ {
+			lst.SetSelected(i, true)
+		}
+	case mod&tcell.ModCtrl != 0:
+		lst.SetSelected(row, !lst.Selected(row))
+	}
+
+	lst.SetCursor(row)
+
+	if double && mod == tcell.ModNone {
+		app.mouse.lastClick = time.Time{}
+		app.activate()
+	}
+}
+
+// activate runs the command bound to <Enter> in the current context.
+func (app *Application) activate() {
+	enter := keysequence.KeySequence{tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)}
+	bind := app.api.Sequencer().Lookup(enter, commands.Contexts(app.api))
+	if bind == nil {
+		return
+	}
+	app.exec(bind.Command)
+}
+
+// exec sends a command triggered by the mouse to the main command queue.
+// The queue is read by the same goroutine that handles mouse events, so the command
+// is dropped instead of blocking if the queue is full.
+func (app *Application) exec(command string) {
+	if len(command) == 0 {
+		return
+	}
+	select {
+	case app.commands <- command:
+	default:
+		log.Debugf("Dropping mouse command '%s': command queue is full", command)
+	}
+}
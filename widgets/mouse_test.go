@@ -0,0 +1,104 @@
+package widgets_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/pkg/stylerule"
+	"github.com/ambientsound/visp/player"
+	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/topbar"
+	"github.com/ambientsound/visp/widgets"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+)
+
+func TestTopbarFragmentAt(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(20, 2)
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(style.Stylesheet{})
+
+	matrix, err := topbar.Parse(a, "foo|bar")
+	require.NoError(t, err)
+
+	bar := widgets.NewTopbar(a)
+	bar.SetView(views.NewViewPort(screen, 0, 0, 20, 1))
+	bar.SetMatrix(matrix)
+	bar.Draw()
+
+	// "foo" is aligned left, and "bar" is aligned right.
+	frag, x, width := bar.FragmentAt(1, 0)
+	if assert.NotNil(t, frag) {
+		text, _ := frag.Text()
+		assert.Equal(t, "foo", text)
+		assert.Equal(t, 1, x)
+		assert.Equal(t, 3, width)
+	}
+
+	frag, x, _ = bar.FragmentAt(19, 0)
+	if assert.NotNil(t, frag) {
+		text, _ := frag.Text()
+		assert.Equal(t, "bar", text)
+		assert.Equal(t, 2, x)
+	}
+
+	frag, _, _ = bar.FragmentAt(10, 0)
+	assert.Nil(t, frag)
+}
+
+func TestTableRowAt(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(40, 5)
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(style.Stylesheet{})
+	a.On("PlayerStatus").Return(*player.NewState(spotify.PlayerState{}))
+	a.On("StyleRules").Return(stylerule.New())
+
+	lst := list.New()
+	for i := 0; i < 10; i++ {
+		lst.Add(list.NewRow(fmt.Sprint(i), list.DataTypeTrack, map[string]string{"title": fmt.Sprintf("Track %d", i)}))
+	}
+	lst.SetVisibleColumns([]string{"title"})
+
+	table := widgets.NewTable(a)
+	table.SetView(views.NewViewPort(screen, 0, 0, 40, 5))
+	table.SetList(lst)
+	table.Resize()
+	table.Draw()
+
+	// The first line is the header.
+	_, ok := table.RowAt(0)
+	assert.False(t, ok)
+
+	row, ok := table.RowAt(1)
+	assert.True(t, ok)
+	assert.Equal(t, 0, row)
+
+	// Scrolling moves the rows under the mouse.
+	table.ScrollViewport(3, false)
+	row, ok = table.RowAt(4)
+	assert.True(t, ok)
+	assert.Equal(t, 6, row)
+
+	_, ok = table.RowAt(5)
+	assert.False(t, ok)
+
+	key, ok := table.ColumnAt(2)
+	assert.True(t, ok)
+	assert.Equal(t, "title", key)
+
+	_, ok = table.ColumnAt(39)
+	assert.False(t, ok)
+}
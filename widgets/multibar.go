@@ -7,15 +7,17 @@ import (
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/multibar"
 	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/topbar"
 	"github.com/gdamore/tcell/v2"
 	"github.com/gdamore/tcell/v2/views"
 )
 
-// Multibar receives keyboard events, displays status messages, and the position readout.
+// Multibar receives keyboard events, displays status messages, and the status line.
 type Multibar struct {
 	api              api.API
 	view             views.View
 	messageTimestamp time.Time
+	statusline       *topbar.RowStatement
 	views.WidgetWatchers
 	style.Styled
 }
@@ -28,6 +30,12 @@ func NewMultibarWidget(a api.API) *Multibar {
 	}
 }
 
+// SetStatusline sets the pieces drawn to the left and right of the message area
+// when not in input mode. A nil row disables the status line.
+func (w *Multibar) SetStatusline(row *topbar.RowStatement) {
+	w.statusline = row
+}
+
 func (w *Multibar) SetView(view views.View) {
 	w.view = view
 }
@@ -54,10 +62,10 @@ func (w *Multibar) textWithStyle() (string, tcell.Style) {
 	switch {
 	case multibarMode == multibar.ModeInput:
 		w.messageTimestamp = time.Now()
-		return ":" + w.api.Multibar().String(), w.Style("commandText")
+		return w.api.Multibar().Prompt() + w.api.Multibar().String(), w.Style("commandText")
 	case multibarMode == multibar.ModeSearch:
 		w.messageTimestamp = time.Now()
-		return "/" + w.api.Multibar().String(), w.Style("searchText")
+		return w.api.Multibar().Prompt() + w.api.Multibar().String(), w.Style("searchText")
 	case len(sequenceText) > 0:
 		w.messageTimestamp = time.Now()
 		return sequenceText, w.Style("sequenceText")
@@ -75,30 +83,50 @@ func (w *Multibar) textWithStyle() (string, tcell.Style) {
 func (w *Multibar) Draw() {
 	w.SetStylesheet(w.api.Styles())
 	w.view.Clear()
-	w.drawLeft()
-	w.drawRight()
-}
 
-// Draw the statusbar part of the Multibar.
-func (w *Multibar) drawLeft() {
 	text, st := w.textWithStyle()
+	xmax, _ := w.Size()
 
-	for x, r := range text {
-		w.view.SetContent(x, 0, r, []rune{}, st)
-		x++
+	switch w.api.Multibar().Mode() {
+	case multibar.ModeInput, multibar.ModeSearch:
+		drawNext(w.view, 0, 0, text, st)
+		return
+	}
+
+	x := 0
+	if left := w.piece(0); left != nil {
+		for _, fragmentStmt := range left.Fragments {
+			x = drawFragment(w.view, &w.Styled, x, 0, fragmentStmt.Instance)
+		}
+	}
+
+	right := w.piece(1)
+	rightX := xmax
+	if right != nil {
+		rightX -= pieceTextWidth(right)
 	}
-}
 
-func (w *Multibar) drawRight() {
-	st := w.Style("readout")
-	// text := w.api.TableWidget().PositionReadout()
-	// FIXME
-	text := ""
-	x, _ := w.Size()
-	x -= len(text)
+	// The message is cut off where the right-hand piece starts.
 	for _, r := range text {
-		w.view.SetContent(x, 0, r, []rune{}, st)
+		if x >= rightX {
+			break
+		}
+		w.view.SetContent(x, 0, r, nil, st)
 		x++
 	}
 
+	if right != nil {
+		x = rightX
+		for _, fragmentStmt := range right.Fragments {
+			x = drawFragment(w.view, &w.Styled, x, 0, fragmentStmt.Instance)
+		}
+	}
+}
+
+// piece returns a piece of the status line, or nil if it is not configured.
+func (w *Multibar) piece(index int) *topbar.PieceStatement {
+	if w.statusline == nil || index >= len(w.statusline.Pieces) {
+		return nil
+	}
+	return w.statusline.Pieces[index]
 }
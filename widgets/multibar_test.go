@@ -0,0 +1,56 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/input/keys"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/log"
+	"github.com/ambientsound/visp/multibar"
+	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/topbar"
+	"github.com/ambientsound/visp/widgets"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultibarStatusline(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(30, 1)
+
+	lst := list.New()
+	lst.Add(list.NewRow("1", list.DataTypeTrack, nil))
+	lst.SetSelected(0, true)
+
+	input := multibar.New(nil)
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(style.Stylesheet{})
+	a.On("List").Return(lst)
+	a.On("Sequencer").Return(keys.NewSequencer())
+	a.On("Multibar").Return(input)
+
+	matrix, err := topbar.Parse(a, `${selection} |[end]`)
+	require.NoError(t, err)
+
+	bar := widgets.NewMultibarWidget(a)
+	bar.SetView(views.NewViewPort(screen, 0, 0, 30, 1))
+	bar.SetStatusline(matrix.Rows[0])
+
+	// The message is drawn between the pieces, and cut off before the right-hand piece.
+	log.Infof("a message that is far too long to fit")
+	bar.Draw()
+	screen.Show()
+	assert.Equal(t, "1 a message that is far t[end]", screenLine(screen, 0))
+
+	// The status line is hidden while typing a command.
+	input.SetMode(multibar.ModeInput)
+	bar.Draw()
+	screen.Show()
+	assert.Equal(t, ":", screenLine(screen, 0))
+}
@@ -0,0 +1,196 @@
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/style"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+)
+
+// Panes is a widget that shows one or more tables, either side by side or
+// stacked on top of each other. One of the tables has focus, and is the
+// table manipulated by commands.
+type Panes struct {
+	api      api.API
+	tables   []*Table
+	ports    []*views.ViewPort
+	focus    int
+	vertical bool
+
+	view views.View
+	style.Styled
+	views.WidgetWatchers
+}
+
+var _ views.Widget = &Panes{}
+
+var _ api.Panes = &Panes{}
+
+// NewPanes returns a Panes widget with a single table.
+func NewPanes(a api.API) *Panes {
+	p := &Panes{
+		api: a,
+	}
+	p.add(NewTable(a))
+	return p
+}
+
+// add appends a table after the focused pane.
+func (p *Panes) add(table *Table) int {
+	pos := len(p.tables)
+	if pos > 0 {
+		pos = p.focus + 1
+	}
+	port := views.NewViewPort(p.view, 0, 0, 0, 0)
+	table.SetView(port)
+	p.tables = append(p.tables[:pos], append([]*Table{table}, p.tables[pos:]...)...)
+	p.ports = append(p.ports[:pos], append([]*views.ViewPort{port}, p.ports[pos:]...)...)
+	return pos
+}
+
+// Split adds a new pane showing the same list as the focused pane, and focuses it.
+// If vertical is true, panes are placed side by side, otherwise on top of each other.
+// The orientation applies to all panes.
+func (p *Panes) Split(vertical bool) {
+	lst := p.Focused().List()
+	p.vertical = vertical
+	p.focus = p.add(NewTable(p.api))
+	if lst != nil {
+		p.Focused().SetList(lst)
+	}
+	p.Resize()
+}
+
+// Close removes the focused pane. The last pane cannot be closed.
+func (p *Panes) Close() error {
+	if len(p.tables) == 1 {
+		return fmt.Errorf("cannot close the last pane")
+	}
+	p.tables = append(p.tables[:p.focus], p.tables[p.focus+1:]...)
+	p.ports = append(p.ports[:p.focus], p.ports[p.focus+1:]...)
+	if p.focus >= len(p.tables) {
+		p.focus = len(p.tables) - 1
+	}
+	p.Resize()
+	return nil
+}
+
+// Only closes all panes except the focused one.
+func (p *Panes) Only() {
+	p.tables = p.tables[p.focus : p.focus+1]
+	p.ports = p.ports[p.focus : p.focus+1]
+	p.focus = 0
+	p.Resize()
+}
+
+// Focus returns the index of the focused pane.
+func (p *Panes) Focus() int {
+	return p.focus
+}
+
+// SetFocus focuses the pane with the given index.
+func (p *Panes) SetFocus(index int) error {
+	if index < 0 || index >= len(p.tables) {
+		return fmt.Errorf("no such pane: %d", index+1)
+	}
+	p.focus = index
+	return nil
+}
+
+// Focused returns the table in the focused pane.
+func (p *Panes) Focused() *Table {
+	return p.tables[p.focus]
+}
+
+// TableWidgets returns the tables in all panes, in screen order.
+func (p *Panes) TableWidgets() []api.TableWidget {
+	widgets := make([]api.TableWidget, len(p.tables))
+	for i := range p.tables {
+		widgets[i] = p.tables[i]
+	}
+	return widgets
+}
+
+// PaneAt returns the index of the pane at the given position, and the position relative to that pane.
+func (p *Panes) PaneAt(x, y int) (int, int, int, bool) {
+	for i := range p.tables {
+		px, py, pw, ph := p.geometry(i)
+		if x >= px && x < px+pw && y >= py && y < py+ph {
+			return i, x - px, y - py, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// geometry returns the position and size of a pane. Side by side panes
+// are separated by a single column.
+func (p *Panes) geometry(index int) (int, int, int, int) {
+	width, height := p.Size()
+	n := len(p.tables)
+	if p.vertical {
+		size := (width - (n - 1)) / n
+		x := index * (size + 1)
+		if index == n-1 {
+			size = width - x
+		}
+		return x, 0, size, height
+	}
+	size := height / n
+	y := index * size
+	if index == n-1 {
+		size = height - y
+	}
+	return 0, y, width, size
+}
+
+// Draw implements views.Widget.
+func (p *Panes) Draw() {
+	p.SetStylesheet(p.api.Styles())
+	for i, table := range p.tables {
+		table.SetFocused(i == p.focus || len(p.tables) == 1)
+		table.Draw()
+		if p.vertical && i > 0 {
+			x, y, _, height := p.geometry(i)
+			st := p.Style("paneSeparator")
+			for ; y < height; y++ {
+				p.view.SetContent(x-1, y, tcell.RuneVLine, nil, st)
+			}
+		}
+	}
+}
+
+// Resize implements views.Widget.
+func (p *Panes) Resize() {
+	if p.view == nil {
+		return
+	}
+	for i, port := range p.ports {
+		port.SetView(p.view)
+		port.Resize(p.geometry(i))
+		// Tables without lists can't compute their column widths yet.
+		if p.tables[i].list != nil {
+			p.tables[i].Resize()
+		}
+	}
+}
+
+// HandleEvent implements views.Widget.
+func (p *Panes) HandleEvent(ev tcell.Event) bool {
+	return false
+}
+
+// SetView implements views.Widget.
+func (p *Panes) SetView(v views.View) {
+	p.view = v
+	p.Resize()
+}
+
+// Size implements views.Widget.
+func (p *Panes) Size() (int, int) {
+	if p.view == nil {
+		return 0, 0
+	}
+	return p.view.Size()
+}
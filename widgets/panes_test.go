@@ -0,0 +1,74 @@
+package widgets_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/pkg/stylerule"
+	"github.com/ambientsound/visp/player"
+	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/widgets"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+)
+
+func testList(name string, rows int) list.List {
+	lst := list.New()
+	lst.SetID(name)
+	lst.SetName(name)
+	for i := 0; i < rows; i++ {
+		lst.Add(list.NewRow(fmt.Sprint(i), list.DataTypeTrack, map[string]string{"title": fmt.Sprintf("%s %d", name, i)}))
+	}
+	lst.SetVisibleColumns([]string{"title"})
+	return lst
+}
+
+func TestPanes(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(41, 6)
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(style.Stylesheet{})
+	a.On("PlayerStatus").Return(*player.NewState(spotify.PlayerState{}))
+	a.On("StyleRules").Return(stylerule.New())
+
+	panes := widgets.NewPanes(a)
+	panes.SetView(views.NewViewPort(screen, 0, 0, 41, 6))
+	panes.Focused().SetList(testList("left", 3))
+	panes.Resize()
+
+	assert.Error(t, panes.Close())
+
+	// A new pane shows the same list, and receives focus.
+	panes.Split(true)
+	assert.Len(t, panes.TableWidgets(), 2)
+	assert.Equal(t, 1, panes.Focus())
+	assert.Equal(t, "left", panes.Focused().List().Name())
+
+	panes.Focused().SetList(testList("right", 3))
+	panes.Draw()
+	screen.Show()
+
+	// Side by side panes are separated by a vertical line.
+	assert.Equal(t, "left 0              │right 0", screenLine(screen, 1))
+
+	pane, x, y, ok := panes.PaneAt(25, 2)
+	assert.True(t, ok)
+	assert.Equal(t, 1, pane)
+	assert.Equal(t, 4, x)
+	assert.Equal(t, 2, y)
+
+	assert.NoError(t, panes.SetFocus(0))
+	assert.Error(t, panes.SetFocus(2))
+
+	assert.NoError(t, panes.Close())
+	assert.Len(t, panes.TableWidgets(), 1)
+	assert.Equal(t, "right", panes.Focused().List().Name())
+}
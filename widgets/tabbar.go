@@ -0,0 +1,123 @@
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/db"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/style"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+)
+
+// Tabbar is a single line widget listing all open windows, highlighting the active one.
+// Windows with changes that are not yet saved to Spotify are marked with a plus sign.
+type Tabbar struct {
+	api api.API
+
+	// tabs are the screen positions of tabs drawn during the last Draw.
+	tabs []tab
+
+	view views.View
+	style.Styled
+	views.WidgetWatchers
+}
+
+// tab is the area covered by a single window in the tab bar.
+type tab struct {
+	x, width int
+	index    int
+}
+
+var _ views.Widget = &Tabbar{}
+
+func NewTabbar(a api.API) *Tabbar {
+	return &Tabbar{
+		api: a,
+	}
+}
+
+// Labels returns the text of each tab, and the index of the active window.
+func (w *Tabbar) Labels() ([]string, int) {
+	windows := w.api.Db()
+	labels := make([]string, windows.Len())
+	for i := range labels {
+		lst := windows.Row(i).(*db.Row).List()
+		labels[i] = fmt.Sprintf(" %d %s", i+1, lst.Name())
+		if lst.HasLocalChanges() {
+			labels[i] += "+"
+		}
+		labels[i] += " "
+	}
+	return labels, windows.Cursor()
+}
+
+// Draw implements views.Widget. If the tabs don't fit on screen, leftmost tabs
+// are hidden until the active tab is visible.
+func (w *Tabbar) Draw() {
+	w.SetStylesheet(w.api.Styles())
+	w.view.Fill(' ', w.Style("tabbar"))
+	w.tabs = w.tabs[:0]
+
+	width, _ := w.Size()
+	labels, active := w.Labels()
+
+	first := 0
+	for first < active && textWidth(labels[first:active+1]) > width {
+		first++
+	}
+
+	x := 0
+	for i := first; i < len(labels) && x < width; i++ {
+		st := w.Style("tab")
+		if i == active {
+			st = w.Style("activeTab")
+		}
+		start := x
+		for _, r := range labels[i] {
+			w.view.SetContent(x, 0, r, nil, st)
+			x++
+		}
+		w.tabs = append(w.tabs, tab{start, x - start, i})
+	}
+}
+
+// textWidth returns the combined length of all strings.
+func textWidth(labels []string) int {
+	width := 0
+	for _, label := range labels {
+		width += len([]rune(label))
+	}
+	return width
+}
+
+// TabAt returns the window index of the tab drawn at the given horizontal position.
+func (w *Tabbar) TabAt(x int) (int, bool) {
+	for _, t := range w.tabs {
+		if x >= t.x && x < t.x+t.width {
+			return t.index, true
+		}
+	}
+	return 0, false
+}
+
+func (w *Tabbar) HandleEvent(ev tcell.Event) bool {
+	return false
+}
+
+// Size implements views.Widget. The tab bar takes up no space unless the `tabbar` option is set.
+func (w *Tabbar) Size() (int, int) {
+	x, _ := w.view.Size()
+	if !options.GetBool(options.Tabbar) {
+		return x, 0
+	}
+	return x, 1
+}
+
+func (w *Tabbar) Resize() {
+}
+
+func (w *Tabbar) SetView(v views.View) {
+	w.view = v
+}
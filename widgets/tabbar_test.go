@@ -0,0 +1,67 @@
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/db"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/widgets"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTabbar(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(30, 1)
+
+	windows := db.New()
+	windows.Cache(testList("Log", 1))
+
+	playlist := testList("Mix", 0)
+	playlist.SetRemote(true)
+	playlist.SetSyncedToRemote()
+	playlist.Add(list.NewRow("x", list.DataTypeTrack, nil))
+	windows.SetCursor(windows.Cache(playlist))
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(style.Stylesheet{})
+	a.On("Db").Return(windows)
+
+	bar := widgets.NewTabbar(a)
+	bar.SetView(views.NewViewPort(screen, 0, 0, 30, 1))
+
+	labels, active := bar.Labels()
+	assert.Equal(t, []string{" 1 Log ", " 2 Mix+ "}, labels)
+	assert.Equal(t, 1, active)
+
+	options.Set(options.Tabbar, false)
+	_, height := bar.Size()
+	assert.Equal(t, 0, height)
+
+	options.Set(options.Tabbar, true)
+	defer options.Set(options.Tabbar, false)
+	_, height = bar.Size()
+	assert.Equal(t, 1, height)
+
+	bar.Draw()
+	screen.Show()
+	assert.Equal(t, " 1 Log  2 Mix+", screenLine(screen, 0))
+
+	index, ok := bar.TabAt(9)
+	assert.True(t, ok)
+	assert.Equal(t, 1, index)
+
+	// Tabs to the left are hidden if the active tab doesn't fit.
+	screen.SetSize(10, 1)
+	bar.SetView(views.NewViewPort(screen, 0, 0, 10, 1))
+	bar.Draw()
+	screen.Show()
+	assert.Equal(t, " 2 Mix+", screenLine(screen, 0))
+}
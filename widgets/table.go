@@ -9,6 +9,7 @@ import (
 	"github.com/ambientsound/visp/list"
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/format"
 	"github.com/ambientsound/visp/style"
 	"github.com/ambientsound/visp/utils"
 
@@ -22,6 +23,7 @@ type lineStyler func(row list.Row) (string, bool)
 type Table struct {
 	api     api.API
 	columns []column
+	focused bool
 	list    list.List
 
 	view     views.View
@@ -34,7 +36,8 @@ type Table struct {
 
 func NewTable(a api.API) *Table {
 	return &Table{
-		api: a,
+		api:     a,
+		focused: true,
 	}
 }
 
@@ -86,12 +89,15 @@ func (w *Table) Draw() {
 	xmax += 1
 	cursor := false
 
-	trackID = w.api.PlayerStatus().TrackRow.ID()
-	deviceID = string(w.api.PlayerStatus().Device.ID)
+	status := w.api.PlayerStatus()
+	trackID = status.TrackRow.ID()
+	deviceID = string(status.Device.ID)
 
 	// Generic line styling.
 	styler = func(row list.Row) (string, bool) {
 		switch {
+		case cursor && !w.focused:
+			return `inactiveCursor`, true
 		case cursor:
 			return `cursor`, true
 		case row.Kind() == list.DataTypeTrack && row.ID() == trackID:
@@ -101,7 +107,7 @@ func (w *Table) Draw() {
 		case w.list.Selected(y):
 			return `selection`, true
 		default:
-			return `default`, false
+			return w.api.StyleRules().Style(w.ruleFields(row))
 		}
 	}
 
@@ -121,7 +127,7 @@ func (w *Table) Draw() {
 		// Draw each column separately
 		for _, col := range w.columns {
 
-			runes := []rune(row.Fields()[col.key])
+			runes := col.text(row.Fields()[col.key])
 			if !lineStyled {
 				st = w.Style(col.key)
 			}
@@ -133,21 +139,69 @@ func (w *Table) Draw() {
 	}
 }
 
+// ruleFields returns the fields that style rules are matched against.
+// Whether a track is liked is added to its fields once it has been looked up,
+// which only happens if a rule needs to know.
+func (w *Table) ruleFields(row list.Row) map[string]string {
+	fields := row.Fields()
+	if _, ok := fields["liked"]; ok || row.Kind() != list.DataTypeTrack || !w.api.StyleRules().Uses("liked") {
+		return fields
+	}
+	liked, known := w.api.Likes().Get(row.ID())
+	if !known {
+		return fields
+	}
+	merged := make(map[string]string, len(fields)+1)
+	for key, value := range fields {
+		merged[key] = value
+	}
+	merged["liked"] = utils.HumanFormatBool(liked)
+	return merged
+}
+
 func (w *Table) drawHeaders() {
 	x := 0
 	st := w.Style("header")
 	for _, col := range w.columns {
-		runes := []rune(col.title)
+		runes := format.Fit(col.title, col.width-col.rightPadding, col.align, format.TruncateCut)
 		strmin := col.width - col.rightPadding
 		x = w.drawNext(w.view, x, 0, strmin, col.width, runes, st)
 	}
 }
 
+// SetFocused sets whether the table is in the focused pane.
+// The cursor of tables without focus is drawn with the `inactiveCursor` style.
+func (w *Table) SetFocused(focused bool) {
+	w.focused = focused
+}
+
 func (w *Table) GetVisibleBoundaries() (ymin, ymax int) {
 	_, ymin, _, ymax = w.viewport.GetVisible()
 	return
 }
 
+// RowAt returns the list index of the row drawn at the given line of the widget.
+// Returns false if the line is the header, or if there is no row at that line.
+func (w *Table) RowAt(y int) (int, bool) {
+	ymin, ymax := w.GetVisibleBoundaries()
+	row := ymin + y - 1
+	if y < 1 || row > ymax || !w.list.InRange(row) {
+		return 0, false
+	}
+	return row, true
+}
+
+// ColumnAt returns the key of the column drawn at the given horizontal position.
+func (w *Table) ColumnAt(x int) (string, bool) {
+	for _, col := range w.columns {
+		if x < col.width {
+			return col.key, true
+		}
+		x -= col.width
+	}
+	return "", false
+}
+
 // Width returns the widget width.
 func (w *Table) Width() int {
 	_, _, xmax, _ := w.viewport.GetVisible()
@@ -210,21 +264,18 @@ func (w *Table) Name() string {
 }
 
 // PositionReadout returns a combination of PositionLongReadout() and PositionShortReadout().
-// FIXME: move this into a positionreadout fragment
 func (w *Table) PositionReadout() string {
 	return fmt.Sprintf("%s    %s", w.PositionLongReadout(), w.PositionShortReadout())
 }
 
 // PositionLongReadout returns a formatted string containing the visible song
 // range as well as the total number of songs.
-// FIXME: move this into a positionreadout fragment
 func (w *Table) PositionLongReadout() string {
 	ymin, ymax := w.GetVisibleBoundaries()
 	return fmt.Sprintf("%d,%d-%d/%d", w.list.Cursor()+1, ymin+1, ymax+1, w.list.Len())
 }
 
 // PositionShortReadout returns a percentage indicator on how far the songlist is scrolled.
-// FIXME: move this into a positionreadout fragment
 func (w *Table) PositionShortReadout() string {
 	ymin, ymax := w.GetVisibleBoundaries()
 	if ymin == 0 && ymax+1 == w.list.Len() {
@@ -272,10 +323,16 @@ func (w *Table) SetColumns(tags []string) {
 	}
 
 	for i, key := range tags {
-		w.columns[i].col = cols[i]
 		w.columns[i].key = key
 		w.columns[i].title = ColumnTitle(key)
-		if expand[key] {
+		w.columns[i].configure(totalWidth)
+		cols[i] = w.columns[i].measure(w.list, cols[i])
+		w.columns[i].col = cols[i]
+		if w.columns[i].fixedWidth > 0 {
+			// columns with a configured width never change size.
+			w.columns[i].width = w.columns[i].fixedWidth + 1
+			expand[key] = false
+		} else if expand[key] {
 			// auto-expanded columns start at their median size
 			w.columns[i].width = cols[i].Median()
 		} else if fullHeader[key] {
@@ -0,0 +1,113 @@
+package widgets_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ambientsound/visp/api"
+	"github.com/ambientsound/visp/list"
+	"github.com/ambientsound/visp/options"
+	"github.com/ambientsound/visp/pkg/format"
+	"github.com/ambientsound/visp/pkg/stylerule"
+	"github.com/ambientsound/visp/player"
+	"github.com/ambientsound/visp/spotify/likes"
+	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/widgets"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/views"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zmb3/spotify/v2"
+)
+
+func TestTableStyleRules(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(40, 7)
+
+	styles := style.Stylesheet{
+		"cursor":   tcell.StyleDefault.Reverse(true),
+		"explicit": tcell.StyleDefault.Foreground(tcell.ColorRed),
+		"liked":    tcell.StyleDefault.Bold(true),
+		"obscure":  tcell.StyleDefault.Dim(true),
+		"title":    tcell.StyleDefault.Foreground(tcell.ColorWhite),
+	}
+
+	rules := stylerule.New()
+	for _, r := range [][2]string{{"explicit", "explicit"}, {"obscure", "popularity<0.20"}, {"liked", "liked"}} {
+		rule, err := stylerule.Parse(r[0], r[1])
+		require.NoError(t, err)
+		rules.Add(rule)
+	}
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(styles)
+	a.On("PlayerStatus").Return(*player.NewState(spotify.PlayerState{}))
+	a.On("StyleRules").Return(rules)
+
+	// The liked status of tracks is known once it has been looked up.
+	likes := spotify_likes.New()
+	likes.Set("4", true)
+	likes.Set("5", false)
+	a.On("Likes").Return(likes)
+
+	lst := list.New()
+	lst.Add(list.NewRow("0", list.DataTypeTrack, map[string]string{"title": "Cursor", "explicit": "yes"}))
+	lst.Add(list.NewRow("1", list.DataTypeTrack, map[string]string{"title": "Explicit", "explicit": "yes", "popularity": "0.10"}))
+	lst.Add(list.NewRow("2", list.DataTypeTrack, map[string]string{"title": "Obscure", "explicit": "no", "popularity": "0.10"}))
+	lst.Add(list.NewRow("3", list.DataTypeTrack, map[string]string{"title": "Popular", "explicit": "no", "popularity": "0.80"}))
+	lst.Add(list.NewRow("4", list.DataTypeTrack, map[string]string{"title": "Liked", "explicit": "no", "popularity": "0.80"}))
+	lst.Add(list.NewRow("5", list.DataTypeTrack, map[string]string{"title": "Not liked", "explicit": "no", "popularity": "0.80"}))
+	lst.SetVisibleColumns([]string{"title"})
+
+	table := widgets.NewTable(a)
+	table.SetView(views.NewViewPort(screen, 0, 0, 40, 7))
+	table.SetList(lst)
+	table.Resize()
+	table.Draw()
+
+	// Rows are drawn below the header line. The cursor takes precedence over rules,
+	// and the first matching rule wins.
+	for y, name := range []string{"cursor", "explicit", "obscure", "title", "liked", "title"} {
+		_, _, st, _ := screen.GetContent(0, y+1)
+		assert.Equal(t, styles[name], st, "row %d", y)
+	}
+}
+
+// Auto-sized columns are wide enough for their formatted values.
+func TestTableFormattedColumnWidth(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(60, 3)
+
+	key := options.ColumnOption("year", options.ColumnFormat)
+	options.Set(key, "relative")
+	defer options.Set(key, "")
+
+	a := &api.MockAPI{}
+	a.On("Styles").Return(style.Stylesheet{})
+	a.On("PlayerStatus").Return(*player.NewState(spotify.PlayerState{}))
+	a.On("StyleRules").Return(stylerule.New())
+
+	lst := list.New()
+	lst.Add(list.NewRow("0", list.DataTypeTrack, map[string]string{"year": "2006", "title": "Song"}))
+	lst.SetVisibleColumns([]string{"year", "title"})
+
+	table := widgets.NewTable(a)
+	table.SetView(views.NewViewPort(screen, 0, 0, 60, 3))
+	table.SetList(lst)
+	table.Resize()
+	table.Draw()
+
+	expected := format.Relative("2006")
+	require.Greater(t, len(expected), len("2006"))
+
+	text := make([]rune, 0)
+	for x := 0; x < 60; x++ {
+		r, _, _, _ := screen.GetContent(x, 1)
+		text = append(text, r)
+	}
+	assert.Equal(t, expected+" Song", strings.TrimSpace(string(text)))
+}
@@ -1,6 +1,8 @@
 package widgets
 
 import (
+	"unicode/utf8"
+
 	"github.com/ambientsound/visp/api"
 	"github.com/ambientsound/visp/log"
 	"github.com/ambientsound/visp/style"
@@ -24,11 +26,20 @@ type Topbar struct {
 	height int // height is both physical and matrix height
 	matrix *topbar.MatrixStatement
 
+	// regions are the screen positions of fragments drawn during the last Draw.
+	regions []region
+
 	view views.View
 	style.Styled
 	views.WidgetWatchers
 }
 
+// region is the area covered by a single fragment.
+type region struct {
+	x, y, width int
+	fragment    topbar.Fragment
+}
+
 // NewTopbar creates a new Topbar widget in the desired dimensions.
 func NewTopbar(a api.API) *Topbar {
 	return &Topbar{
@@ -53,6 +64,7 @@ func (w *Topbar) Draw() {
 
 	// Blank screen first
 	w.view.Fill(' ', w.Style("topbar"))
+	w.regions = w.regions[:0]
 
 	for y, rowStmt := range w.matrix.Rows {
 		// Calculate window buffer width
@@ -72,18 +84,30 @@ func (w *Topbar) Draw() {
 
 			for _, fragmentStmt := range pieceStmt.Fragments {
 				frag := fragmentStmt.Instance
-				text, styleStr := frag.Text()
-				style := w.Style(styleStr)
-				x = w.drawNext(x, y, text, style)
+				start := x
+				x = drawFragment(w.view, &w.Styled, x, y, frag)
+				w.regions = append(w.regions, region{start, y, x - start, frag})
 			}
 		}
 	}
 }
 
+// drawFragment draws a fragment, using its segments if available, and returns the resulting X position.
+func drawFragment(v views.View, styled *style.Styled, x, y int, frag topbar.Fragment) int {
+	if segmented, ok := frag.(topbar.Segmented); ok {
+		for _, segment := range segmented.Segments() {
+			x = drawNext(v, x, y, segment.Text, styled.Style(segment.Style))
+		}
+		return x
+	}
+	text, styleStr := frag.Text()
+	return drawNext(v, x, y, text, styled.Style(styleStr))
+}
+
 // drawNext draws a string and returns the resulting X position.
-func (w *Topbar) drawNext(x, y int, s string, style tcell.Style) int {
+func drawNext(v views.View, x, y int, s string, style tcell.Style) int {
 	for _, r := range s {
-		w.view.SetContent(x, y, r, nil, style)
+		v.SetContent(x, y, r, nil, style)
 		x++
 	}
 	return x
@@ -132,11 +156,23 @@ func pieceTextWidth(piece *topbar.PieceStatement) int {
 	width := 0
 	for _, fragment := range piece.Fragments {
 		s, _ := fragment.Instance.Text()
-		width += len(s)
+		width += utf8.RuneCountInString(s)
 	}
 	return width
 }
 
+// FragmentAt returns the fragment drawn at a position, along with the position
+// relative to the start of the fragment and the fragment width.
+// Returns nil if there is no fragment at that position.
+func (w *Topbar) FragmentAt(x, y int) (topbar.Fragment, int, int) {
+	for _, r := range w.regions {
+		if r.y == y && x >= r.x && x < r.x+r.width {
+			return r.fragment, x - r.x, r.width
+		}
+	}
+	return nil, 0, 0
+}
+
 func (w *Topbar) HandleEvent(ev tcell.Event) bool {
 	return false
 }
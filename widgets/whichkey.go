@@ -0,0 +1,97 @@
+package widgets
+
+import (
+	"github.com/ambientsound/visp/input/keys"
+	"github.com/ambientsound/visp/keysequence"
+	"github.com/ambientsound/visp/style"
+	"github.com/ambientsound/visp/utils"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	whichKeyBorder = '─'
+	whichKeyGap    = 2
+)
+
+// WhichKey is a popup listing the key bindings that can complete the key sequence typed so far.
+type WhichKey struct {
+	prefix   int
+	bindings []keys.Binding
+	style.Styled
+}
+
+// Set shows the bindings in the popup. The first prefix keys of each sequence have already
+// been typed, and are not shown. The popup is hidden if there are no bindings.
+func (w *WhichKey) Set(prefix int, bindings []keys.Binding) {
+	w.prefix = prefix
+	w.bindings = bindings
+}
+
+// Hide hides the popup.
+func (w *WhichKey) Hide() {
+	w.Set(0, nil)
+}
+
+// Visible returns true if the popup has anything to show.
+func (w *WhichKey) Visible() bool {
+	return len(w.bindings) > 0
+}
+
+// Draw draws the popup on top of the screen contents, directly above the
+// bottom lines of the screen. Bindings are laid out in as many columns as will fit.
+func (w *WhichKey) Draw(screen tcell.Screen, bottom int) {
+	if !w.Visible() {
+		return
+	}
+
+	width, height := screen.Size()
+
+	sequences := make([][]rune, len(w.bindings))
+	commands := make([][]rune, len(w.bindings))
+	keyWidth, commandWidth := 0, 0
+	for i, bind := range w.bindings {
+		sequences[i] = []rune(keysequence.KeySequence(bind.Sequence[w.prefix:]).String())
+		commands[i] = []rune(bind.Command)
+		keyWidth = utils.Max(keyWidth, len(sequences[i]))
+		commandWidth = utils.Max(commandWidth, len(commands[i]))
+	}
+
+	// Commands are truncated if even a single column won't fit.
+	commandWidth = utils.Min(commandWidth, width-keyWidth-whichKeyGap*2-1)
+	cellWidth := 1 + keyWidth + whichKeyGap + commandWidth + whichKeyGap
+	columns := utils.Max(1, width/cellWidth)
+
+	// Use at most half the screen height.
+	rows := (len(w.bindings) + columns - 1) / columns
+	rows = utils.Min(rows, (height-bottom)/2-1)
+	top := height - bottom - rows - 1
+	if rows < 1 || top < 0 {
+		return
+	}
+
+	st := w.Style("whichKey")
+	for x := 0; x < width; x++ {
+		screen.SetContent(x, top, whichKeyBorder, nil, st)
+		for y := top + 1; y <= top+rows; y++ {
+			screen.SetContent(x, y, ' ', nil, st)
+		}
+	}
+
+	for i := range w.bindings {
+		col, row := i/rows, i%rows
+		if col >= columns {
+			break
+		}
+		x := col*cellWidth + 1
+		y := top + 1 + row
+		drawRunes(screen, x, y, sequences[i], keyWidth, w.Style("keyBinding"))
+		drawRunes(screen, x+keyWidth+whichKeyGap, y, commands[i], commandWidth, w.Style("command"))
+	}
+}
+
+// drawRunes draws text on the screen, truncated to a maximum width.
+func drawRunes(screen tcell.Screen, x, y int, text []rune, width int, st tcell.Style) {
+	for i := 0; i < len(text) && i < width; i++ {
+		screen.SetContent(x+i, y, text[i], nil, st)
+	}
+}
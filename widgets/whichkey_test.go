@@ -0,0 +1,60 @@
+package widgets_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ambientsound/visp/input/keys"
+	"github.com/ambientsound/visp/keysequence"
+	"github.com/ambientsound/visp/widgets"
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func binding(input string, command string) keys.Binding {
+	seq := make(keysequence.KeySequence, 0)
+	for _, r := range input {
+		seq = append(seq, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	return keys.Binding{Sequence: seq, Command: command}
+}
+
+// screenLine returns the text on one line of a simulated screen.
+func screenLine(screen tcell.SimulationScreen, y int) string {
+	cells, width, _ := screen.GetContents()
+	runes := make([]rune, width)
+	for x := 0; x < width; x++ {
+		runes[x] = cells[y*width+x].Runes[0]
+	}
+	return strings.TrimRight(string(runes), " ")
+}
+
+func TestWhichKey(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	screen.SetSize(40, 10)
+
+	popup := &widgets.WhichKey{}
+	assert.False(t, popup.Visible())
+
+	popup.Set(1, []keys.Binding{
+		binding("gc", "cursor current"),
+		binding("gg", "cursor home"),
+		binding("gt", "list next"),
+	})
+	assert.True(t, popup.Visible())
+
+	popup.Draw(screen, 1)
+	screen.Show()
+
+	// Two columns fit on screen, the bindings are laid out column by column above the bottom line.
+	assert.Equal(t, strings.Repeat("─", 40), screenLine(screen, 6))
+	assert.Equal(t, " c  cursor current   t  list next", screenLine(screen, 7))
+	assert.Equal(t, " g  cursor home", screenLine(screen, 8))
+	assert.Equal(t, "", screenLine(screen, 9))
+
+	popup.Hide()
+	assert.False(t, popup.Visible())
+}
@@ -14,7 +14,7 @@ const (
 	progName = "visp"
 )
 
-// appendProgDirectory adds "pms" to a directory tree.
+// appendProgDirectory adds "visp" to a directory tree.
 func appendProgDirectory(dir string) string {
 	return path.Join(dir, progName)
 }
@@ -57,7 +57,7 @@ func ConfigDirectories() []string {
 	return dirs
 }
 
-// CacheDirectory returns the cache base directory.
+// CacheDirectory returns the directory where cached files should be stored.
 func CacheDirectory() string {
 	// $XDG_CACHE_HOME defines the base directory relative to which user
 	// specific non-essential data files should be stored. If $XDG_CACHE_HOME is
@@ -67,5 +67,33 @@ func CacheDirectory() string {
 		xdgCacheHome = path.Join(os.Getenv("HOME"), ".cache")
 	}
 
-	return path.Join(xdgCacheHome, "pms")
+	return appendProgDirectory(xdgCacheHome)
+}
+
+// DataDirectory returns the directory where persistent user data should be stored.
+func DataDirectory() string {
+	// $XDG_DATA_HOME defines the base directory relative to which user-specific
+	// data files should be stored. If $XDG_DATA_HOME is either not set or empty,
+	// a default equal to $HOME/.local/share should be used.
+	xdgDataHome := os.Getenv("XDG_DATA_HOME")
+	if len(xdgDataHome) == 0 {
+		xdgDataHome = path.Join(os.Getenv("HOME"), ".local", "share")
+	}
+
+	return appendProgDirectory(xdgDataHome)
+}
+
+// RuntimeDirectory returns the directory where sockets and other runtime
+// files should be placed.
+func RuntimeDirectory() string {
+	// $XDG_RUNTIME_DIR defines the base directory relative to which user-specific
+	// non-essential runtime files and other file objects (such as sockets, named
+	// pipes, ...) should be stored. If $XDG_RUNTIME_DIR is not set, fall back
+	// to the cache directory.
+	xdgRuntimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if len(xdgRuntimeDir) == 0 {
+		return CacheDirectory()
+	}
+
+	return appendProgDirectory(xdgRuntimeDir)
 }